@@ -0,0 +1,132 @@
+package radixsort
+
+import (
+	"unsafe"
+
+	"github.com/sagernet/sing/common/x/constraints"
+)
+
+// batchKernelMin is the slice length starting from which a batch slice is
+// sorted with the unrolled kernel of its width instead of the shared-table
+// radix sort, whose per-element digit loop is slower on long inputs.
+const batchKernelMin = 1 << 16
+
+// BatchUint64 sorts every slice of batch independently in ascending order.
+//
+// It is intended for workloads that sort many short slices, where the setup
+// of the offset tables would dominate a call to [Uint64] per slice. The
+// algorithm is picked per slice: insertion sort for very short slices, a
+// radix sort over only the bytes that differ within the slice with one offset
+// table shared across the whole batch, and the regular kernel for long slices.
+//
+// The buf slice is shared scratch space and must be at least as long as the
+// longest slice of the batch.
+//
+// Returns ErrInvalidBufferSize if buf is shorter than the longest slice;
+// no slice is modified in that case.
+//
+// Example:
+//
+//	batch := [][]uint64{{3, 1, 2}, {9, 7}, {5}}
+//	buf := make([]uint64, 3)
+//	err := BatchUint64(batch, buf)
+//	// batch is now: [[1 2 3] [7 9] [5]]
+func BatchUint64(batch [][]uint64, buf []uint64) error {
+	return sortBatch(batch, buf, 0, radix64)
+}
+
+// BatchUint32 sorts every slice of batch independently in ascending order.
+//
+// See [BatchUint64] for details.
+func BatchUint32(batch [][]uint32, buf []uint32) error {
+	return sortBatch(batch, buf, 0, radix32)
+}
+
+// BatchUint16 sorts every slice of batch independently in ascending order.
+//
+// See [BatchUint64] for details.
+func BatchUint16(batch [][]uint16, buf []uint16) error {
+	return sortBatch(batch, buf, 0, radix16b8)
+}
+
+// BatchUint8 sorts every slice of batch independently in ascending order.
+//
+// See [BatchUint64] for details.
+func BatchUint8(batch [][]uint8, buf []uint8) error {
+	return sortBatch(batch, buf, 0, radix8)
+}
+
+// BatchInt64 sorts every slice of batch independently in ascending order.
+//
+// See [BatchUint64] for details.
+func BatchInt64(batch [][]int64, buf []uint64) error {
+	return sortBatch(*(*[][]uint64)(unsafe.Pointer(&batch)), buf, 1<<63, radix64)
+}
+
+// BatchInt32 sorts every slice of batch independently in ascending order.
+//
+// See [BatchUint64] for details.
+func BatchInt32(batch [][]int32, buf []uint32) error {
+	return sortBatch(*(*[][]uint32)(unsafe.Pointer(&batch)), buf, 1<<31, radix32)
+}
+
+// BatchInt16 sorts every slice of batch independently in ascending order.
+//
+// See [BatchUint64] for details.
+func BatchInt16(batch [][]int16, buf []uint16) error {
+	return sortBatch(*(*[][]uint16)(unsafe.Pointer(&batch)), buf, 1<<15, radix16b8)
+}
+
+// BatchInt8 sorts every slice of batch independently in ascending order.
+//
+// See [BatchUint64] for details.
+func BatchInt8(batch [][]int8, buf []uint8) error {
+	return sortBatch(*(*[][]uint8)(unsafe.Pointer(&batch)), buf, 1<<7, radix8)
+}
+
+// sortBatch sorts every slice of batch. Signed integers are passed in their
+// unsigned representation together with their sign bit, which is flipped
+// before and after sorting so that negative values order first.
+func sortBatch[T constraints.Unsigned](batch [][]T, buf []T, signBit T, kernel func(data, buf []T) error) error {
+	longest := 0
+	for _, data := range batch {
+		longest = max(longest, len(data))
+	}
+	if len(buf) < longest {
+		return ErrInvalidBufferSize
+	}
+
+	var offsets [8][256]uint
+	for _, data := range batch {
+		if len(data) < 2 {
+			continue
+		}
+
+		if signBit != 0 {
+			flipBits(data, signBit)
+		}
+
+		switch {
+		case len(data) <= shortInsertionMax:
+			insertionSort(data)
+		case len(data) >= batchKernelMin:
+			_ = kernel(data, buf)
+		default:
+			digits, n := varyingDigits(data)
+			radixSegment(data, buf, digits[:n], &offsets)
+		}
+
+		if signBit != 0 {
+			flipBits(data, signBit)
+		}
+	}
+
+	return nil
+}
+
+// flipBits toggles the bits of mask in every element of data.
+func flipBits[T constraints.Unsigned](data []T, mask T) {
+	for i := range data {
+		data[i] ^= mask
+	}
+}
@@ -0,0 +1,88 @@
+package radixsort_test
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/constraints"
+)
+
+func TestBatchUint8(t *testing.T) {
+	testBatchSort(t, radixsort.BatchUint8, "BatchUint8")
+}
+
+func TestBatchUint16(t *testing.T) {
+	testBatchSort(t, radixsort.BatchUint16, "BatchUint16")
+}
+
+func TestBatchUint32(t *testing.T) {
+	testBatchSort(t, radixsort.BatchUint32, "BatchUint32")
+}
+
+func TestBatchUint64(t *testing.T) {
+	testBatchSort(t, radixsort.BatchUint64, "BatchUint64")
+}
+
+func TestBatchInt8(t *testing.T) {
+	testBatchSort(t, radixsort.BatchInt8, "BatchInt8")
+}
+
+func TestBatchInt16(t *testing.T) {
+	testBatchSort(t, radixsort.BatchInt16, "BatchInt16")
+}
+
+func TestBatchInt32(t *testing.T) {
+	testBatchSort(t, radixsort.BatchInt32, "BatchInt32")
+}
+
+func TestBatchInt64(t *testing.T) {
+	testBatchSort(t, radixsort.BatchInt64, "BatchInt64")
+}
+
+func testBatchSort[T constraints.Integer, B constraints.Unsigned](t *testing.T, sortFunc func([][]T, []B) error, sortFuncName string) {
+	lengths := []int{0, 1, 2, 5, 48, 49, 50, 100, 500, 1000, 70_000}
+
+	batch := make([][]T, 0, 2*len(lengths))
+	for _, n := range append(lengths, lengths...) {
+		data := make([]T, n)
+		shift := rand.Intn(64)
+		for i := range data {
+			data[i] = T(rand.Uint64() >> shift)
+		}
+		batch = append(batch, data)
+	}
+
+	want := make([][]T, len(batch))
+	for i, data := range batch {
+		want[i] = append([]T{}, data...)
+		slices.Sort(want[i])
+	}
+
+	buf := make([]B, 70_000)
+	if err := sortFunc(batch, buf); err != nil {
+		t.Fatalf("%s failed: %v", sortFuncName, err)
+	}
+
+	for i := range batch {
+		if !slices.Equal(want[i], batch[i]) {
+			t.Errorf("%s: slice %d of length %d is not sorted correctly", sortFuncName, i, len(batch[i]))
+		}
+	}
+
+	t.Run("BufferTooSmall", func(t *testing.T) {
+		in := [][]T{{3, 1, 2}, {5, 4, 3, 2, 1}}
+		batch := [][]T{append([]T{}, in[0]...), append([]T{}, in[1]...)}
+
+		err := sortFunc(batch, make([]B, 4))
+		if !errors.Is(err, radixsort.ErrInvalidBufferSize) {
+			t.Errorf("%s: error = %v, want %v", sortFuncName, err, radixsort.ErrInvalidBufferSize)
+		}
+		if !cmp.Equal(in, batch) {
+			t.Errorf("%s: batch modified on error: %v", sortFuncName, batch)
+		}
+	})
+}
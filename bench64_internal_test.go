@@ -0,0 +1,36 @@
+package radixsort
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// BenchmarkCombinedScatter runs the 8-bit LSD kernel with the write-combined
+// and the plain scatter loop on the same random 64-bit keys, on inputs around
+// and beyond the size of the L2 cache (see combinedScatterMinLen).
+func BenchmarkCombinedScatter(b *testing.B) {
+	for _, size := range []int{1 << 16, 1 << 18, 1 << 20, 1 << 22} {
+		data := make([]uint64, size)
+		for i := range data {
+			data[i] = rand.Uint64()
+		}
+		tmp := make([]uint64, size)
+		buf := make([]uint64, size)
+
+		for _, combined := range []bool{false, true} {
+			name := "Plain"
+			if combined {
+				name = "Combined"
+			}
+			b.Run(fmt.Sprintf("%s_%d", name, size), func(b *testing.B) {
+				for b.Loop() {
+					copy(tmp, data)
+					if err := radix64b8Passes(tmp, buf, 0, combined); err != nil {
+						b.Fatalf("radix64b8Passes failed: %v", err)
+					}
+				}
+			})
+		}
+	}
+}
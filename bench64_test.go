@@ -6,6 +6,7 @@ import (
 	"slices"
 	"testing"
 
+	"github.com/Kaidzen-62/radixsort"
 	rdxsort "github.com/loov/radixsort"
 	"github.com/twotwotwo/sorts/sortutil"
 )
@@ -80,3 +81,42 @@ func BenchmarkTwoTwoTwoRadixSort64(b *testing.B) {
 		}
 	}
 }
+
+// largeSizes exceed the L2 cache, where the scatter passes switch to
+// write-combining buffers.
+var largeSizes = []int{1 << 18, 1 << 20, 1 << 22}
+
+// BenchmarkLargeUint64 compares the sorters on random 64-bit keys spanning
+// the full range, on inputs around and beyond the size of the L2 cache.
+func BenchmarkLargeUint64(b *testing.B) {
+	for _, size := range largeSizes {
+		data := make([]uint64, size)
+		for i := range data {
+			data[i] = r.Uint64()
+		}
+		tmp := make([]uint64, size)
+		buf := make([]uint64, size)
+		runtime.GC()
+
+		b.Run(fmt.Sprintf("RadixsortUint64_%d", size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp, data)
+				if err := radixsort.Uint64(tmp, buf); err != nil {
+					b.Fatalf("Uint64 failed: %v", err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("RadixSortByLoovUint64_%d", size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp, data)
+				rdxsort.Uint64(tmp, buf)
+			}
+		})
+		b.Run(fmt.Sprintf("RadixSortByTwoTwoTwoUint64_%d", size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp, data)
+				sortutil.Uint64Slice(tmp).Sort()
+			}
+		})
+	}
+}
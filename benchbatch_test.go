@@ -0,0 +1,62 @@
+package radixsort_test
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+// batchSizes are the numbers of slices per batch for the batch benchmarks.
+var batchSizes = []int{100, 1000}
+
+func generateBatch(count int) [][]uint64 {
+	batch := make([][]uint64, count)
+	for i := range batch {
+		batch[i] = make([]uint64, 50+rand.Intn(451))
+		for j := range batch[i] {
+			batch[i][j] = rand.Uint64()
+		}
+	}
+	return batch
+}
+
+func cloneBatch(dst, src [][]uint64) {
+	for i := range src {
+		dst[i] = append(dst[i][:0], src[i]...)
+	}
+}
+
+func BenchmarkBatchUint64(b *testing.B) {
+	for _, count := range batchSizes {
+		batch := generateBatch(count)
+		tmp := make([][]uint64, len(batch))
+		buf := make([]uint64, 500)
+
+		b.Run(fmt.Sprintf("BatchUint64_%d", count), func(b *testing.B) {
+			runtime.GC()
+			b.ResetTimer()
+			for b.Loop() {
+				cloneBatch(tmp, batch)
+				if err := radixsort.BatchUint64(tmp, buf); err != nil {
+					b.Fatalf("BatchUint64 failed: %v", err)
+				}
+			}
+		})
+
+		b.Run(fmt.Sprintf("PerSliceUint64_%d", count), func(b *testing.B) {
+			runtime.GC()
+			b.ResetTimer()
+			for b.Loop() {
+				cloneBatch(tmp, batch)
+				for _, data := range tmp {
+					if err := radixsort.Uint64(data, buf); err != nil {
+						b.Fatalf("Uint64 failed: %v", err)
+					}
+				}
+			}
+		})
+	}
+}
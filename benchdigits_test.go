@@ -0,0 +1,166 @@
+package radixsort_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+	"unsafe"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+// BenchmarkDigits sorts inputs around the length where the kernels switch
+// from 8-bit to 11-bit digits, with keys of different ranges.
+func BenchmarkDigits(b *testing.B) {
+	for _, size := range []int{1 << 16, 1 << 18, 1 << 20, 1 << 22} {
+		for _, span := range []uint{22, 32, 64} {
+			data := make([]uint64, size)
+			for i := range data {
+				data[i] = rand.Uint64() >> (64 - span)
+			}
+			tmp := make([]uint64, size)
+			buf := make([]uint64, size)
+
+			b.Run(fmt.Sprintf("RadixsortUint64_%d_%dbits", size, span), func(b *testing.B) {
+				for b.Loop() {
+					copy(tmp, data)
+					if err := radixsort.Uint64(tmp, buf); err != nil {
+						b.Fatalf("Uint64 failed: %v", err)
+					}
+				}
+			})
+
+			if span > 32 {
+				continue
+			}
+			data32 := make([]uint32, size)
+			for i, v := range data {
+				data32[i] = uint32(v)
+			}
+			tmp32 := make([]uint32, size)
+			buf32 := make([]uint32, size)
+
+			b.Run(fmt.Sprintf("RadixsortUint32_%d_%dbits", size, span), func(b *testing.B) {
+				for b.Loop() {
+					copy(tmp32, data32)
+					if err := radixsort.Uint32(tmp32, buf32); err != nil {
+						b.Fatalf("Uint32 failed: %v", err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkTimestamps sorts nanosecond timestamps within a one-hour window,
+// whose varying bits cross a byte boundary, and their offsets from the start
+// of the window, which need no realignment of the digits.
+func BenchmarkTimestamps(b *testing.B) {
+	const start = 1_760_000_000_000_000_000
+	const window = 3600_000_000_000
+
+	for _, size := range []int{1 << 16, 1 << 20} {
+		data := make([]uint64, size)
+		offsets := make([]uint64, size)
+		for i := range data {
+			offsets[i] = uint64(rand.Int63n(window))
+			data[i] = start + offsets[i]
+		}
+		tmp := make([]uint64, size)
+		buf := make([]uint64, size)
+
+		b.Run(fmt.Sprintf("RadixsortUint64_%d", size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp, data)
+				if err := radixsort.Uint64(tmp, buf); err != nil {
+					b.Fatalf("Uint64 failed: %v", err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("RadixsortUint64Offsets_%d", size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp, offsets)
+				if err := radixsort.Uint64(tmp, buf); err != nil {
+					b.Fatalf("Uint64 failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// lsd16 is a reference LSD radix sort with 16-bit digits, which the kernels
+// do not use, see BenchmarkWideDigits. counts must hold 1<<16 elements.
+func lsd16[T uint16 | uint32](data, buf []T, counts []uint) {
+	src, dst := data, buf[:len(data)]
+	for shift := 0; shift < int(unsafe.Sizeof(T(0)))*8; shift += 16 {
+		clear(counts)
+		for _, v := range src {
+			counts[uint16(v>>shift)]++
+		}
+		offset := uint(0)
+		for d, c := range counts {
+			counts[d], offset = offset, offset+c
+		}
+		for _, v := range src {
+			d := uint16(v >> shift)
+			dst[counts[d]] = v
+			counts[d]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &data[0] {
+		copy(data, src)
+	}
+}
+
+// BenchmarkWideDigits compares the kernels with 16-bit digits: two passes for
+// uint32, and a single pass for uint16, which Uint16 makes by counting once
+// there are four elements per value.
+func BenchmarkWideDigits(b *testing.B) {
+	counts := make([]uint, 1<<16)
+	for _, size := range []int{1 << 16, 1 << 18, 1 << 20, 1 << 22, 1 << 24} {
+		data := make([]uint32, size)
+		for i := range data {
+			data[i] = rand.Uint32()
+		}
+		tmp := make([]uint32, size)
+		buf := make([]uint32, size)
+
+		b.Run(fmt.Sprintf("RadixsortUint32_%d", size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp, data)
+				if err := radixsort.Uint32(tmp, buf); err != nil {
+					b.Fatalf("Uint32 failed: %v", err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("LSD16Uint32_%d", size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp, data)
+				lsd16(tmp, buf, counts)
+			}
+		})
+
+		data16 := make([]uint16, size)
+		for i, v := range data {
+			data16[i] = uint16(v)
+		}
+		tmp16 := make([]uint16, size)
+		buf16 := make([]uint16, size)
+
+		b.Run(fmt.Sprintf("RadixsortUint16_%d", size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp16, data16)
+				if err := radixsort.Uint16(tmp16, buf16); err != nil {
+					b.Fatalf("Uint16 failed: %v", err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("LSD16Uint16_%d", size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp16, data16)
+				lsd16(tmp16, buf16, counts)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package radixsort_test
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
 	"runtime"
 	"testing"
 
@@ -48,6 +50,14 @@ func BenchmarkGenericStruct(b *testing.B) {
 	benchmarkGenericStruct(b, radixsort.Generic, key, "GenericPoint3")
 }
 
+func BenchmarkGenericCachedStruct(b *testing.B) {
+	key := func(p Point3) int64 {
+		return p.Z
+	}
+
+	benchmarkGenericStruct(b, radixsort.GenericCached, key, "GenericCachedPoint3")
+}
+
 func benchmarkGenericSimple[T radixsort.ConstraintNumbers](b *testing.B, sortFunc genericSortFunc[T, T], keyFunc genericKeyFunc[T, T], sortFuncName string) {
 	for _, size := range sizes {
 		for _, mode := range modes {
@@ -126,3 +136,71 @@ func generatePoint3Data(n int, mode string) []Point3 {
 
 	return data
 }
+
+type elem8 struct{ K int64 }
+
+type elem32 struct {
+	K int64
+	_ [24]byte
+}
+
+type elem256 struct {
+	K int64
+	_ [248]byte
+}
+
+// BenchmarkGenericElemSize compares Generic, which moves the elements on every
+// pass, with the key/index pairs of GenericCached for a cheap key and keys of
+// one, two and eight varying bytes. Generic sorts pairs by itself once it
+// would move 64 bytes per element, see cachedKeyMinMoved.
+func BenchmarkGenericElemSize(b *testing.B) {
+	for _, keyBytes := range []int{1, 2, 8} {
+		keys := fmt.Sprintf("%dbyte", keyBytes)
+		benchmarkGenericCaching(b, func(e elem8) int64 { return e.K }, func(k int64) elem8 { return elem8{K: k} }, keyBytes, "8B"+keys)
+		benchmarkGenericCaching(b, func(e elem32) int64 { return e.K }, func(k int64) elem32 { return elem32{K: k} }, keyBytes, "32B"+keys)
+		benchmarkGenericCaching(b, func(e elem256) int64 { return e.K }, func(k int64) elem256 { return elem256{K: k} }, keyBytes, "256B"+keys)
+	}
+}
+
+// BenchmarkGenericKeyCost compares Generic with GenericCached for a key that
+// is expensive to compute, on elements that Generic moves.
+func BenchmarkGenericKeyCost(b *testing.B) {
+	key := func(e elem8) int64 {
+		x := float64(e.K)
+		return int64(math.Sqrt(x*x + 3*x + 1))
+	}
+
+	benchmarkGenericCaching(b, key, func(k int64) elem8 { return elem8{K: k} }, 4, "Sqrt")
+}
+
+// benchmarkGenericCaching sorts elements with random keys of keyBytes varying
+// bytes with Generic and GenericCached.
+func benchmarkGenericCaching[E any, N radixsort.ConstraintNumbers](b *testing.B, key genericKeyFunc[E, N], newElem func(int64) E, keyBytes int, name string) {
+	sortFuncs := []struct {
+		name     string
+		sortFunc genericSortFunc[E, N]
+	}{
+		{name: "Generic", sortFunc: radixsort.Generic[E, N]},
+		{name: "GenericCached", sortFunc: radixsort.GenericCached[E, N]},
+	}
+
+	for _, size := range sizes {
+		data := make([]E, size)
+		for i := range data {
+			data[i] = newElem(int64(rand.Uint64() >> (64 - 8*keyBytes)))
+		}
+		buf := make([]E, size)
+		tmp := make([]E, size)
+
+		for _, sf := range sortFuncs {
+			b.Run(fmt.Sprintf("Radixsort%s%s_%d", sf.name, name, size), func(b *testing.B) {
+				for b.Loop() {
+					copy(tmp, data)
+					if err := sf.sortFunc(tmp, buf, key); err != nil {
+						b.Fatalf("%s failed: %v", sf.name, err)
+					}
+				}
+			})
+		}
+	}
+}
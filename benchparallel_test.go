@@ -0,0 +1,48 @@
+package radixsort_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+// BenchmarkParallelUint64 compares the sequential sort with the parallel
+// sort on 1, 2, 4 and all available workers. The speedup is bounded by the
+// memory bandwidth rather than the number of cores.
+func BenchmarkParallelUint64(b *testing.B) {
+	workers := []int{1, 2, 4}
+	if n := runtime.GOMAXPROCS(0); n > 4 {
+		workers = append(workers, n)
+	}
+	for _, size := range largeSizes {
+		data := make([]uint64, size)
+		for i := range data {
+			data[i] = r.Uint64()
+		}
+		tmp := make([]uint64, size)
+		buf := make([]uint64, size)
+		runtime.GC()
+
+		b.Run(fmt.Sprintf("Uint64_%d", size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp, data)
+				if err := radixsort.Uint64(tmp, buf); err != nil {
+					b.Fatalf("Uint64 failed: %v", err)
+				}
+			}
+		})
+		for _, w := range workers {
+			p := radixsort.Parallel{Workers: w, Threshold: 1}
+			b.Run(fmt.Sprintf("Parallel%d_%d", w, size), func(b *testing.B) {
+				for b.Loop() {
+					copy(tmp, data)
+					if err := p.Uint64(tmp, buf); err != nil {
+						b.Fatalf("Parallel.Uint64 failed: %v", err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package radixsort_test
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+// segmentLengths are average segment lengths for the segmented benchmarks.
+var segmentLengths = []int{8, 64, 512}
+
+func generateSegments(n, avg int) []int {
+	segments := []int{0}
+	for segments[len(segments)-1] < n {
+		segments = append(segments, min(segments[len(segments)-1]+1+rand.Intn(2*avg), n))
+	}
+	return segments
+}
+
+func BenchmarkSegmentedUint32(b *testing.B) {
+	for _, size := range sizes {
+		for _, avg := range segmentLengths {
+			data := generateData[uint32](size, "random")
+			segments := generateSegments(size, avg)
+			buf := make([]uint32, len(data))
+
+			b.Run(fmt.Sprintf("SegmentedUint32_%d_avg%d", size, avg), func(b *testing.B) {
+				runtime.GC()
+				b.ResetTimer()
+				for b.Loop() {
+					tmp := append([]uint32{}, data...)
+					if err := radixsort.SegmentedUint32(tmp, buf, segments); err != nil {
+						b.Fatalf("SegmentedUint32 failed: %v", err)
+					}
+				}
+			})
+
+			b.Run(fmt.Sprintf("PerSegmentUint32_%d_avg%d", size, avg), func(b *testing.B) {
+				runtime.GC()
+				b.ResetTimer()
+				for b.Loop() {
+					tmp := append([]uint32{}, data...)
+					for k := 1; k < len(segments); k++ {
+						if err := radixsort.Uint32(tmp[segments[k-1]:segments[k]], buf); err != nil {
+							b.Fatalf("Uint32 failed: %v", err)
+						}
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package radixsort_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"golang.org/x/exp/constraints"
+)
+
+// smallSizes covers the crossover between insertion sort and radix sort.
+var smallSizes = []int{16, 32, 64, 128, 256, 512}
+
+// BenchmarkSmall compares the sorters with the standard library on short
+// random inputs. It is used to validate the insertion sort thresholds.
+func BenchmarkSmall(b *testing.B) {
+	benchmarkSmall(b, radixsort.Uint8, "Uint8")
+	benchmarkSmall(b, radixsort.Uint16, "Uint16")
+	benchmarkSmall(b, radixsort.Uint32, "Uint32")
+	benchmarkSmall(b, radixsort.Uint64, "Uint64")
+}
+
+func benchmarkSmall[T constraints.Unsigned](b *testing.B, sortFunc func([]T, []T) error, sortFuncName string) {
+	for _, size := range smallSizes {
+		data := generateData[T](size, "random")
+		tmp := make([]T, size)
+		buf := make([]T, size)
+
+		b.Run(fmt.Sprintf("Radixsort%s_%d", sortFuncName, size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp, data)
+				if err := sortFunc(tmp, buf); err != nil {
+					b.Fatalf("%s failed: %v", sortFuncName, err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("StdLib%s_%d", sortFuncName, size), func(b *testing.B) {
+			for b.Loop() {
+				copy(tmp, data)
+				slices.Sort(tmp)
+			}
+		})
+	}
+}
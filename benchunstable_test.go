@@ -0,0 +1,49 @@
+package radixsort_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"golang.org/x/exp/constraints"
+)
+
+// BenchmarkUnstable compares the unstable sorts with the stable sorts of the
+// same width on the datasets of the other benchmarks.
+func BenchmarkUnstable(b *testing.B) {
+	benchmarkUnstable(b, radixsort.Uint32, radixsort.UnstableUint32, "Uint32")
+	benchmarkUnstable(b, radixsort.Uint64, radixsort.UnstableUint64, "Uint64")
+}
+
+func benchmarkUnstable[T constraints.Unsigned](b *testing.B, stable func([]T, []T) error, unstable func([]T, []T), name string) {
+	for _, size := range sizes {
+		for _, mode := range modes {
+			data := generateData[T](size, mode)
+			tmp := make([]T, len(data))
+			buf := make([]T, len(data))
+			runtime.GC()
+
+			b.Run(fmt.Sprintf("Stable%s_%d_%s", name, size, mode), func(b *testing.B) {
+				for b.Loop() {
+					copy(tmp, data)
+					if err := stable(tmp, buf); err != nil {
+						b.Fatalf("%s failed: %v", name, err)
+					}
+				}
+			})
+			b.Run(fmt.Sprintf("Unstable%s_%d_%s", name, size, mode), func(b *testing.B) {
+				for b.Loop() {
+					copy(tmp, data)
+					unstable(tmp, buf)
+				}
+			})
+			b.Run(fmt.Sprintf("UnstableNoBuffer%s_%d_%s", name, size, mode), func(b *testing.B) {
+				for b.Loop() {
+					copy(tmp, data)
+					unstable(tmp, nil)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package radixsort
+
+import (
+	"slices"
+
+	"github.com/sagernet/sing/common/x/constraints"
+)
+
+// MinBufferSize is the smallest buffer length accepted for data longer than
+// the buffer.
+//
+// A buffer shorter than the data is not an error as long as it holds at
+// least min(len(data), MinBufferSize) elements. The sort then runs within the
+// memory of the given buffer, so memory-constrained callers can choose their
+// scratch size explicitly and trade speed for memory:
+//
+//   - The integer sorts partition the data in place by its most significant
+//     bytes (see [InPlaceUint64]) until every bucket fits the buffer, and then
+//     radix sort each bucket with the buffer as usual.
+//   - [Generic] and [GenericCached] radix sort chunks of len(buf) elements and
+//     merge them stably, using the buffer to merge wherever one of the runs
+//     fits into it and rotations elsewhere.
+//
+// Both fallbacks are slower than a sort with a full-size buffer, but the
+// results are the same: the order of equal elements is preserved in the
+// generic case, and indistinguishable for plain integers.
+const MinBufferSize = 256
+
+// checkBuffer reports whether a buffer of bufLen elements is too short to sort
+// n elements, even in the bounded memory mode.
+func checkBuffer(n, bufLen int) error {
+	if bufLen < min(n, MinBufferSize) {
+		return ErrInvalidBufferSize
+	}
+	return nil
+}
+
+// sortUnsigned sorts data with kernel if buf is large enough, and otherwise
+// with an MSD partitioning that calls kernel on buckets that fit into buf.
+// shift is the position of the most significant byte of T.
+func sortUnsigned[T constraints.Unsigned](data, buf []T, shift uint, kernel func(data, buf []T) error) error {
+	if len(buf) >= len(data) {
+		return sortShort(data, buf, kernel)
+	}
+	if err := checkBuffer(len(data), len(buf)); err != nil {
+		return err
+	}
+
+	americanFlag(data, buf, shift, kernel)
+	return nil
+}
+
+// sortSignedBounded sorts signed integers in their unsigned representation
+// when buf is shorter than data. The sign bit is flipped before and after
+// sorting so that negative values order first, which unlike the rotation of
+// the full-buffer path needs no scratch memory.
+func sortSignedBounded[T constraints.Unsigned](data, buf []T, signBit T, shift uint, kernel func(data, buf []T) error) error {
+	if err := checkBuffer(len(data), len(buf)); err != nil {
+		return err
+	}
+
+	flipBits(data, signBit)
+	americanFlag(data, buf, shift, kernel)
+	flipBits(data, signBit)
+	return nil
+}
+
+// sortChunked sorts data stably when buf is shorter than data: chunks of
+// len(buf) elements are sorted with sortChunk and then merged pairwise.
+func sortChunked[E any](data, buf []E, key func(a E) uint64, sortChunk func(data, buf []E) error) error {
+	if err := checkBuffer(len(data), len(buf)); err != nil {
+		return err
+	}
+
+	chunk := len(buf)
+	for start := 0; start < len(data); start += chunk {
+		end := min(start+chunk, len(data))
+		if err := sortChunk(data[start:end], buf); err != nil {
+			return err
+		}
+	}
+
+	for width := chunk; width < len(data); width *= 2 {
+		for start := 0; start+width < len(data); start += 2 * width {
+			end := min(start+2*width, len(data))
+			mergeBounded(data[start:end], width, buf, key)
+		}
+	}
+
+	return nil
+}
+
+// mergeBounded stably merges the sorted runs data[:mid] and data[mid:].
+//
+// If one of the runs fits into buf, it is moved there and merged back in
+// a single linear pass. Otherwise both runs are split so that the middle part
+// can be exchanged by a rotation, as in the SymMerge algorithm, and the two
+// halves are merged recursively.
+func mergeBounded[E any](data []E, mid int, buf []E, key func(a E) uint64) {
+	if mid == 0 || mid == len(data) || key(data[mid-1]) <= key(data[mid]) {
+		return
+	}
+
+	switch {
+	case mid <= len(buf):
+		left := buf[:mid]
+		copy(left, data[:mid])
+		i, j, k := 0, mid, 0
+		for i < len(left) && j < len(data) {
+			// Take from the right run only if it is strictly smaller,
+			// which keeps equal elements in their original order.
+			if key(data[j]) < key(left[i]) {
+				data[k] = data[j]
+				j++
+			} else {
+				data[k] = left[i]
+				i++
+			}
+			k++
+		}
+		copy(data[k:], left[i:])
+
+	case len(data)-mid <= len(buf):
+		right := buf[:len(data)-mid]
+		copy(right, data[mid:])
+		i, j, k := mid-1, len(right)-1, len(data)-1
+		for i >= 0 && j >= 0 {
+			if key(right[j]) < key(data[i]) {
+				data[k] = data[i]
+				i--
+			} else {
+				data[k] = right[j]
+				j--
+			}
+			k--
+		}
+		copy(data[:j+1], right[:j+1])
+
+	default:
+		var cut1, cut2 int
+		if mid >= len(data)-mid {
+			// Split the left run in half; right elements smaller than the
+			// pivot go before it.
+			cut1 = mid / 2
+			k := key(data[cut1])
+			cut2 = mid + lowerBound(data[mid:], func(e E) bool { return key(e) >= k })
+		} else {
+			// Split the right run in half; left elements not greater than
+			// the pivot stay before it.
+			cut2 = mid + (len(data)-mid)/2
+			k := key(data[cut2])
+			cut1 = lowerBound(data[:mid], func(e E) bool { return key(e) > k })
+		}
+
+		rotate(data[cut1:cut2], mid-cut1)
+		newMid := cut1 + cut2 - mid
+		mergeBounded(data[:newMid], cut1, buf, key)
+		mergeBounded(data[newMid:], cut2-newMid, buf, key)
+	}
+}
+
+// lowerBound returns the index of the first element of the partitioned slice
+// data for which pred is true, or len(data) if there is none.
+func lowerBound[E any](data []E, pred func(e E) bool) int {
+	lo, hi := 0, len(data)
+	for lo < hi {
+		h := int(uint(lo+hi) >> 1)
+		if pred(data[h]) {
+			hi = h
+		} else {
+			lo = h + 1
+		}
+	}
+	return lo
+}
+
+// rotate moves data[:mid] behind data[mid:] by three reversals.
+func rotate[E any](data []E, mid int) {
+	slices.Reverse(data[:mid])
+	slices.Reverse(data[mid:])
+	slices.Reverse(data)
+}
@@ -0,0 +1,113 @@
+package radixsort_test
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"golang.org/x/exp/constraints"
+)
+
+// boundedBufSizes are the buffer lengths used to sort 100k elements in the
+// bounded memory mode, from the minimum up to one element too short.
+var boundedBufSizes = []int{radixsort.MinBufferSize, 1000, 33_333, 99_999}
+
+func testBoundedSort[T constraints.Integer, B constraints.Unsigned](t *testing.T, sortFunc func([]T, []B) error, sortFuncName string) {
+	const size = 100_000
+	input := make([]T, size)
+	for i := range input {
+		input[i] = T(rand.Uint64())
+	}
+	want := slices.Clone(input)
+	slices.Sort(want)
+
+	for _, bufSize := range boundedBufSizes {
+		data := slices.Clone(input)
+		buf := make([]B, bufSize)
+
+		if err := sortFunc(data, buf); err != nil {
+			t.Fatalf("%s with buffer of %d: %v", sortFuncName, bufSize, err)
+		}
+		if !slices.Equal(want, data) {
+			t.Errorf("%s with buffer of %d failed to sort data correctly", sortFuncName, bufSize)
+		}
+	}
+
+	data := slices.Clone(input)
+	err := sortFunc(data, make([]B, radixsort.MinBufferSize-1))
+	if !errors.Is(err, radixsort.ErrInvalidBufferSize) {
+		t.Errorf("%s: error = %v, want %v", sortFuncName, err, radixsort.ErrInvalidBufferSize)
+	}
+	if !slices.Equal(input, data) {
+		t.Errorf("%s modified data despite the error", sortFuncName)
+	}
+}
+
+func TestBoundedUnsigned(t *testing.T) {
+	// Uint8 does not use its buffer, so it accepts buffers of any length.
+	testSortIgnoresBuffer(t, radixsort.Uint8, "Uint8", 100_000)
+	testBoundedSort(t, radixsort.Uint16, "Uint16")
+	testBoundedSort(t, radixsort.Uint32, "Uint32")
+	testBoundedSort(t, radixsort.Uint64, "Uint64")
+}
+
+func TestBoundedSigned(t *testing.T) {
+	testSortIgnoresBuffer(t, radixsort.Int8, "Int8", 100_000)
+	testBoundedSort(t, radixsort.Int16, "Int16")
+	testBoundedSort(t, radixsort.Int32, "Int32")
+	testBoundedSort(t, radixsort.Int64, "Int64")
+}
+
+func TestBoundedGeneric(t *testing.T) {
+	type small struct {
+		Key int16
+		ID  int32
+	}
+	type large struct {
+		Key     float64
+		ID      int
+		Payload [2]int
+	}
+
+	const size = 100_000
+	smalls := make([]small, size)
+	larges := make([]large, size)
+	for i := range size {
+		// Few distinct keys, so that the stability of the merges matters.
+		smalls[i] = small{Key: int16(rand.Intn(200) - 100), ID: int32(i)}
+		larges[i] = large{Key: float64(rand.Intn(200)-100) / 4, ID: i}
+	}
+
+	wantSmall := slices.Clone(smalls)
+	slices.SortStableFunc(wantSmall, func(a, b small) int { return cmp.Compare(a.Key, b.Key) })
+	wantLarge := slices.Clone(larges)
+	slices.SortStableFunc(wantLarge, func(a, b large) int { return cmp.Compare(a.Key, b.Key) })
+
+	for _, bufSize := range boundedBufSizes {
+		data := slices.Clone(smalls)
+		err := radixsort.Generic(data, make([]small, bufSize), func(a small) int16 { return a.Key })
+		if err != nil {
+			t.Fatalf("Generic with buffer of %d: %v", bufSize, err)
+		}
+		if !slices.Equal(wantSmall, data) {
+			t.Errorf("Generic with buffer of %d: result is not a stable sort of the input", bufSize)
+		}
+
+		cached := slices.Clone(larges)
+		err = radixsort.GenericCached(cached, make([]large, bufSize), func(a large) float64 { return a.Key })
+		if err != nil {
+			t.Fatalf("GenericCached with buffer of %d: %v", bufSize, err)
+		}
+		if !slices.Equal(wantLarge, cached) {
+			t.Errorf("GenericCached with buffer of %d: result is not a stable sort of the input", bufSize)
+		}
+	}
+
+	err := radixsort.Generic(smalls, make([]small, radixsort.MinBufferSize-1), func(a small) int16 { return a.Key })
+	if !errors.Is(err, radixsort.ErrInvalidBufferSize) {
+		t.Errorf("Generic: error = %v, want %v", err, radixsort.ErrInvalidBufferSize)
+	}
+}
@@ -0,0 +1,202 @@
+package radixsort
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"time"
+)
+
+// CalibrateOptions configures [Calibrate].
+type CalibrateOptions struct {
+	// MaxLen is the longest input measured; zero means 1<<23 elements, which
+	// takes about 200 MiB of memory. Thresholds that need longer inputs to
+	// be measured keep their current values.
+	MaxLen int
+	// Log, if not nil, receives a line describing every measurement.
+	Log func(msg string)
+}
+
+// Calibration parameters.
+const (
+	// calibrateMaxLen is the default of CalibrateOptions.MaxLen.
+	calibrateMaxLen = 1 << 23
+	// calibrateRounds is the number of timings per measurement, of which
+	// the fastest counts. Taking the minimum filters out interruptions.
+	calibrateRounds = 3
+	// calibrateBatchLen is the number of elements sorted per timing of
+	// short inputs, so that the timer resolution does not matter.
+	calibrateBatchLen = 1 << 16
+)
+
+// Candidate input lengths of the thresholds measured by Calibrate.
+var (
+	smallSortLens       = []int{8, 16, 24, 32, 48, 64, 96, 128, 192, 256, 384, 512}
+	wideDigitLens       = []int{1 << 20, 1 << 21, 1 << 22, 1 << 23}
+	combinedScatterLens = []int{1 << 16, 1 << 17, 1 << 18, 1 << 19, 1 << 20, 1 << 21, 1 << 22}
+	hybridLens          = []int{1 << 17, 1 << 18, 1 << 19, 1 << 20, 1 << 21, 1 << 22}
+	parallelLens        = []int{1 << 14, 1 << 15, 1 << 16, 1 << 17, 1 << 18, 1 << 19, 1 << 20, 1 << 21}
+)
+
+// Calibrate measures the thresholds of the sorts on the local machine with
+// micro-benchmarks on random keys and returns them as a profile. It takes
+// from a few seconds to a minute, depending on opts.MaxLen and the machine,
+// and returns ctx.Err() if ctx is done before.
+//
+// Each threshold is the shortest candidate length from which the faster
+// algorithm wins at that and every longer candidate length. An algorithm that
+// never wins sets the threshold beyond the longest candidate. The parallel
+// threshold is measured only with GOMAXPROCS of at least 2.
+//
+// Calibrate does not apply the profile; pass it to [SetProfile] or save it
+// with [Profile.WriteFile]. Run it on an otherwise idle machine.
+//
+// Example:
+//
+//	p, err := radixsort.Calibrate(ctx, radixsort.CalibrateOptions{})
+//	if err != nil {
+//	    return err
+//	}
+//	err = p.WriteFile("radixsort.json")
+func Calibrate(ctx context.Context, opts CalibrateOptions) (Profile, error) {
+	c := calibration{ctx: ctx, maxLen: opts.MaxLen, log: opts.Log}
+	if c.maxLen <= 0 {
+		c.maxLen = calibrateMaxLen
+	}
+
+	p := CurrentProfile()
+	p.Machine = fmt.Sprintf("%s/%s, %d CPUs", runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+
+	calibrateSmall(&c, &p.SmallSortMax8, "uint8", func(data, _ []uint8) { countingSort8(data) })
+	calibrateSmall(&c, &p.SmallSortMax16, "uint16", func(data, buf []uint16) { _ = radix16b8(data, buf) })
+	calibrateSmall(&c, &p.SmallSortMax32, "uint32", func(data, buf []uint32) { _ = radix32b8(data, buf) })
+	calibrateSmall(&c, &p.SmallSortMax64, "uint64", func(data, buf []uint64) { _ = radix64b8(data, buf) })
+
+	c.minLen(&p.WideDigitMinLen, "wide digits (8 vs 11 bits)", wideDigitLens,
+		calibrateKernel[uint32](func(data, buf []uint32) { _ = radix32b8(data, buf) }),
+		calibrateKernel[uint32](func(data, buf []uint32) { radix32b11(data, buf, 0, 0) }))
+
+	c.minLen(&p.CombinedScatterMinLen, "combined scatter (plain vs combined)", combinedScatterLens,
+		calibrateKernel[uint64](func(data, buf []uint64) { _ = radix64b8Passes(data, buf, 0, false) }),
+		calibrateKernel[uint64](func(data, buf []uint64) { _ = radix64b8Passes(data, buf, 0, true) }))
+
+	// The LSD passes that the hybrid sort competes with use the scatter loop
+	// just measured.
+	combinedMinLen := p.CombinedScatterMinLen
+	c.minLen(&p.HybridMinLen, "hybrid (lsd vs hybrid)", hybridLens,
+		calibrateKernel[uint64](func(data, buf []uint64) {
+			_ = radix64b8Passes(data, buf, 0, len(data) >= combinedMinLen)
+		}),
+		calibrateKernel[uint64](func(data, buf []uint64) { hybrid64(data, buf, 0, 7*8, 1) }))
+
+	if runtime.GOMAXPROCS(0) >= 2 {
+		eager := Parallel{Threshold: 1}
+		c.minLen(&p.ParallelThreshold, "parallel (sequential vs parallel)", parallelLens,
+			calibrateKernel[uint64](func(data, buf []uint64) { _ = Uint64(data, buf) }),
+			calibrateKernel[uint64](func(data, buf []uint64) { _ = eager.Uint64(data, buf) }))
+	}
+
+	if c.err != nil {
+		return Profile{}, c.err
+	}
+	return p, nil
+}
+
+// calibration holds the state of a run of Calibrate. Once err is set, the
+// remaining measurements are skipped.
+type calibration struct {
+	ctx    context.Context
+	maxLen int
+	log    func(msg string)
+	err    error
+}
+
+// calibrateKernel times a sort of random keys of type T.
+type calibrateKernel[T uint8 | uint16 | uint32 | uint64] func(data, buf []T)
+
+// time returns the fastest of calibrateRounds timings of copying and sorting
+// random inputs of n keys with k, per input.
+func (k calibrateKernel[T]) time(n int) time.Duration {
+	r := rand.New(rand.NewPCG(uint64(n), 0))
+	input := make([]T, n)
+	for i := range input {
+		input[i] = T(r.Uint64())
+	}
+	data := make([]T, n)
+	buf := make([]T, n)
+
+	// The copies are timed along with the sorts, which keeps the timer out
+	// of the batches of short inputs. Both sides of a comparison pay them.
+	batch := max(calibrateBatchLen/n, 1)
+	fastest := time.Duration(1<<63 - 1)
+	for range calibrateRounds {
+		start := time.Now()
+		for range batch {
+			copy(data, input)
+			k(data, buf)
+		}
+		fastest = min(fastest, time.Since(start)/time.Duration(batch))
+	}
+	return fastest
+}
+
+// timer measures the time of sorting n keys.
+type timer interface {
+	time(n int) time.Duration
+}
+
+// calibrateSmall sets *threshold to the longest candidate length up to which
+// insertion sort beats kernel on keys of type T.
+func calibrateSmall[T uint8 | uint16 | uint32 | uint64](c *calibration, threshold *int, name string, kernel calibrateKernel[T]) {
+	insertion := calibrateKernel[T](func(data, _ []T) { insertionSort(data) })
+	i, ok := c.crossover("small "+name+" (insertion vs radix)", smallSortLens, insertion, kernel)
+	switch {
+	case !ok:
+	case i == 0:
+		*threshold = smallSortLens[0] / 2
+	default:
+		*threshold = smallSortLens[i-1]
+	}
+}
+
+// minLen sets *threshold to the shortest of lens from which fast beats slow,
+// or to twice the longest length if fast does not win at that one.
+func (c *calibration) minLen(threshold *int, name string, lens []int, slow, fast timer) {
+	i, ok := c.crossover(name, lens, slow, fast)
+	switch {
+	case !ok:
+	case i == len(lens):
+		*threshold = 2 * lens[len(lens)-1]
+	default:
+		*threshold = lens[i]
+	}
+}
+
+// crossover times slow and fast on inputs of every length of lens and returns
+// the index of the first length from which fast wins at every longer length,
+// or len(lens) if it does not win at the last. It reports false, measuring
+// nothing, if a length exceeds the maximum or the calibration has failed.
+func (c *calibration) crossover(name string, lens []int, slow, fast timer) (int, bool) {
+	if c.err != nil || lens[len(lens)-1] > c.maxLen {
+		return 0, false
+	}
+
+	first := len(lens)
+	for i, n := range lens {
+		if c.err = c.ctx.Err(); c.err != nil {
+			return 0, false
+		}
+		slowTime, fastTime := slow.time(n), fast.time(n)
+		if c.log != nil {
+			c.log(fmt.Sprintf("%s: n=%d %v vs %v", name, n, slowTime, fastTime))
+		}
+		switch {
+		case fastTime >= slowTime:
+			first = len(lens)
+		case first == len(lens):
+			first = i
+		}
+	}
+	return first, true
+}
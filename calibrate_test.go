@@ -0,0 +1,52 @@
+package radixsort_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+func TestCalibrate(t *testing.T) {
+	// Only the small-sort thresholds are measured up to 4096 elements.
+	var lines []string
+	p, err := radixsort.Calibrate(context.Background(), radixsort.CalibrateOptions{
+		MaxLen: 1 << 12,
+		Log:    func(msg string) { lines = append(lines, msg) },
+	})
+	if err != nil {
+		t.Fatalf("Calibrate failed: %v", err)
+	}
+
+	current := radixsort.CurrentProfile()
+	for name, v := range map[string]int{
+		"SmallSortMax8":  p.SmallSortMax8,
+		"SmallSortMax16": p.SmallSortMax16,
+		"SmallSortMax32": p.SmallSortMax32,
+		"SmallSortMax64": p.SmallSortMax64,
+	} {
+		if v <= 0 || v > 512 {
+			t.Errorf("%s = %d, want a measured length", name, v)
+		}
+	}
+	if p.HybridMinLen != current.HybridMinLen || p.WideDigitMinLen != current.WideDigitMinLen {
+		t.Errorf("Calibrate changed thresholds beyond MaxLen: %+v", p)
+	}
+	if p.Machine == "" {
+		t.Error("Calibrate did not describe the machine")
+	}
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "small uint8") {
+		t.Errorf("log = %q, want the small-sort measurements", lines)
+	}
+	if radixsort.CurrentProfile() != current {
+		t.Error("Calibrate applied the profile")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := radixsort.Calibrate(ctx, radixsort.CalibrateOptions{MaxLen: 1 << 12}); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,457 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// keyField is a struct field used as (part of) the radix key.
+type keyField struct {
+	Name  string // field name
+	Type  string // declared field type, as written in the source
+	Basic string // underlying predeclared type, e.g. "int32" or "float64"
+	Desc  bool
+}
+
+// size returns the key width of the field in bytes.
+func (f keyField) size() int {
+	switch f.Basic {
+	case "int8", "uint8", "byte":
+		return 1
+	case "int16", "uint16":
+		return 2
+	case "int32", "uint32", "rune", "float32":
+		return 4
+	default:
+		return 8
+	}
+}
+
+// keyType returns the unsigned type holding the key of the field.
+func (f keyField) keyType() string {
+	return fmt.Sprintf("uint%d", f.size()*8)
+}
+
+// digit describes one unrolled 8-bit radix pass.
+type digit struct {
+	Index int    // position in the offsets table
+	Key   string // name of the local variable holding the field key
+	Expr  string // key expression for the element variable e
+	Shift int
+}
+
+// config holds everything needed to render a specialized sorter.
+type config struct {
+	Package string
+	Type    string
+	Func    string
+	Fields  []keyField
+	Args    string
+	Keys    []digit // one entry per key field, Shift unused
+	Digits  []digit // least significant digit first
+	Float32 bool
+	Float64 bool
+}
+
+// parseFields parses a comma separated list of field names; a field may be
+// prefixed with '-' to sort it in descending order.
+func parseFields(spec string) ([]keyField, error) {
+	var fields []keyField
+	for name := range strings.SplitSeq(spec, ",") {
+		name = strings.TrimSpace(name)
+		desc := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		if name == "" {
+			return nil, fmt.Errorf("empty field name in %q", spec)
+		}
+		fields = append(fields, keyField{Name: name, Desc: desc})
+	}
+	return fields, nil
+}
+
+// loadPackage parses the Go files of the package in dir that match the
+// current build context, skipping the files listed in skip (typically
+// previously generated output).
+func loadPackage(dir string, skip map[string]bool) (string, []*ast.File, error) {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range pkg.GoFiles {
+		if skip[name] {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return "", nil, err
+		}
+		files = append(files, f)
+	}
+
+	return pkg.Name, files, nil
+}
+
+// resolve looks up typeName among files and fills in the key fields.
+func resolve(files []*ast.File, typeName string, fields []keyField) error {
+	types := map[string]ast.Expr{}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if ts.TypeParams == nil {
+					types[ts.Name.Name] = ts.Type
+				}
+			}
+		}
+	}
+
+	expr, ok := types[typeName]
+	if !ok {
+		return fmt.Errorf("type %s not found", typeName)
+	}
+	st, ok := expr.(*ast.StructType)
+	if !ok {
+		return fmt.Errorf("type %s is not a struct", typeName)
+	}
+
+	declared := map[string]ast.Expr{}
+	for _, field := range st.Fields.List {
+		for _, name := range field.Names {
+			declared[name.Name] = field.Type
+		}
+	}
+
+	for i := range fields {
+		typ, ok := declared[fields[i].Name]
+		if !ok {
+			return fmt.Errorf("struct %s has no field %s", typeName, fields[i].Name)
+		}
+		ident, ok := typ.(*ast.Ident)
+		if !ok {
+			return fmt.Errorf("field %s.%s: unsupported type %s", typeName, fields[i].Name, exprString(typ))
+		}
+		fields[i].Type = ident.Name
+
+		// Follow local named types such as `type Celsius float64` down to
+		// their predeclared underlying type.
+		basic := ident.Name
+		for range len(types) + 1 {
+			next, ok := types[basic].(*ast.Ident)
+			if !ok {
+				break
+			}
+			basic = next.Name
+		}
+		switch basic {
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+			"byte", "rune", "float32", "float64":
+			fields[i].Basic = basic
+		default:
+			return fmt.Errorf("field %s.%s: unsupported type %s", typeName, fields[i].Name, ident.Name)
+		}
+	}
+
+	return nil
+}
+
+// exprString formats a type expression for error messages.
+func exprString(e ast.Expr) string {
+	var buf bytes.Buffer
+	_ = format.Node(&buf, token.NewFileSet(), e)
+	return buf.String()
+}
+
+// newConfig prepares the template data for a sorter of typeName by fields.
+func newConfig(pkg, typeName, funcName string, fields []keyField) *config {
+	c := &config{Package: pkg, Type: typeName, Func: funcName, Fields: fields}
+
+	var args []string
+	for _, f := range fields {
+		if f.Desc {
+			args = append(args, "-"+f.Name)
+		} else {
+			args = append(args, f.Name)
+		}
+	}
+	c.Args = strings.Join(args, ",")
+
+	if c.Func == "" {
+		c.Func = "Sort" + typeName + "By" + strings.Join(fieldNames(fields), "")
+	}
+
+	for i, f := range fields {
+		c.Keys = append(c.Keys, digit{Key: fmt.Sprintf("k%d", i), Expr: c.keyExpr(f)})
+	}
+
+	// The last field is the least significant one, so its low byte is sorted first.
+	for i := len(fields) - 1; i >= 0; i-- {
+		for b := range fields[i].size() {
+			c.Digits = append(c.Digits, digit{
+				Index: len(c.Digits),
+				Key:   c.Keys[i].Key,
+				Expr:  c.Keys[i].Expr,
+				Shift: b * 8,
+			})
+		}
+	}
+
+	return c
+}
+
+// keyExpr returns an expression converting field f of the element e into
+// an unsigned key with the same ordering.
+func (c *config) keyExpr(f keyField) string {
+	field := "e." + f.Name
+	var expr string
+	switch f.Basic {
+	case "float32":
+		c.Float32 = true
+		expr = fmt.Sprintf("%sFloat32Key(float32(%s))", lowerFirst(c.Func), field)
+	case "float64":
+		c.Float64 = true
+		expr = fmt.Sprintf("%sFloat64Key(float64(%s))", lowerFirst(c.Func), field)
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		bits := f.size() * 8
+		expr = fmt.Sprintf("%s(%s)^(1<<%d)", f.keyType(), field, bits-1)
+	default:
+		expr = fmt.Sprintf("%s(%s)", f.keyType(), field)
+	}
+
+	if f.Desc {
+		return "^(" + expr + ")"
+	}
+	return expr
+}
+
+// generate renders the sorter source.
+func generate(c *config) ([]byte, error) {
+	return render(sorterTemplate, c)
+}
+
+// generateTest renders a test comparing the sorter with slices.SortStableFunc.
+func generateTest(c *config) ([]byte, error) {
+	return render(testTemplate, c)
+}
+
+func render(tmpl *template.Template, c *config) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, c); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+func fieldNames(fields []keyField) []string {
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = f.Name
+	}
+	return names
+}
+
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[n:]
+}
+
+func upperFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[n:]
+}
+
+var funcs = template.FuncMap{
+	"lowerFirst": lowerFirst,
+	"upperFirst": upperFirst,
+	"randExpr": func(f keyField) string {
+		switch f.Basic {
+		case "float32", "float64":
+			return fmt.Sprintf("%s(rand.NormFloat64() * float64(rand.Intn(1000)+1))", f.Type)
+		case "int", "int8", "int16", "int32", "int64", "rune":
+			return fmt.Sprintf("%s(int64(rand.Uint64()) >> rand.Intn(64))", f.Type)
+		default:
+			return fmt.Sprintf("%s(rand.Uint64() >> rand.Intn(64))", f.Type)
+		}
+	},
+}
+
+var sorterTemplate = template.Must(template.New("sorter").Funcs(funcs).Parse(`// Code generated by radixgen -type={{.Type}} -fields={{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- if or .Float32 .Float64}}
+	"math"
+{{end}}
+	"github.com/Kaidzen-62/radixsort"
+)
+
+// {{.Func}} sorts a slice of {{.Type}} by {{.Args}} in ascending order.
+//
+// It is a closure-free specialization of radixsort.Generic: the sort is
+// stable, buf must have len(buf) >= len(data), and the buffer can be reused
+// across multiple sort operations without clearing.
+//
+// Returns radixsort.ErrInvalidBufferSize if len(buf) < len(data).
+func {{.Func}}(data, buf []{{.Type}}) error {
+	if len(buf) < len(data) {
+		return radixsort.ErrInvalidBufferSize
+	}
+
+	// offsets[d][b] stores prefix sums (insertion offsets) for digit d and offset b.
+	// First they are used as frequency counters, then converted into offsets.
+	offsets := [{{len .Digits}}][256]uint{}
+	for _, e := range data {
+{{- range .Keys}}
+		{{.Key}} := {{.Expr}}
+{{- end}}
+{{- range .Digits}}
+		offsets[{{.Index}}][uint8({{.Key}}>>{{.Shift}})]++
+{{- end}}
+	}
+
+	// Convert counts into prefix sums (offsets).
+	acc := [{{len .Digits}}]uint{
+{{- range .Digits}}
+		offsets[{{.Index}}][0],
+{{- end}}
+	}
+{{- range .Digits}}
+	offsets[{{.Index}}][0] = 0
+{{- end}}
+	for i := 1; i < 256; i++ {
+{{- range .Digits}}
+		offsets[{{.Index}}][i], acc[{{.Index}}] = acc[{{.Index}}], acc[{{.Index}}]+offsets[{{.Index}}][i]
+{{- end}}
+	}
+
+	// Optimization: skip sorting passes where all elements in the digit are identical.
+	uniqueOffsets := [{{len .Digits}}]uint{}
+	for i := range {{len .Digits}} {
+		if offsets[i][255] == 0 || offsets[i][1] == acc[i] {
+			uniqueOffsets[i] = 1
+			continue
+		}
+
+		for j := 1; j < 256; j++ {
+			if offsets[i][j] != offsets[i][j-1] {
+				uniqueOffsets[i]++
+			}
+
+			if offsets[i][j] == acc[i] {
+				break
+			}
+		}
+
+		if offsets[i][255] != acc[i] {
+			uniqueOffsets[i]++
+		}
+	}
+
+	swaps := 0
+	src, dst := data, buf[:len(data)]
+{{- range .Digits}}
+
+	if uniqueOffsets[{{.Index}}] > 1 {
+		for _, e := range src {
+			b := uint8(({{.Expr}}) >> {{.Shift}})
+			dst[offsets[{{.Index}}][b]] = e
+			offsets[{{.Index}}][b]++
+		}
+		src, dst = dst, src
+		swaps++
+	}
+{{- end}}
+
+	if swaps&1 == 1 {
+		copy(data, src)
+	}
+
+	return nil
+}
+{{- if .Float32}}
+
+// {{lowerFirst .Func}}Float32Key maps a float32 to a uint32 with the same ordering.
+func {{lowerFirst .Func}}Float32Key(f float32) uint32 {
+	k := math.Float32bits(f)
+	return k ^ (uint32(int32(k)>>31) | 1<<31)
+}
+{{- end}}
+{{- if .Float64}}
+
+// {{lowerFirst .Func}}Float64Key maps a float64 to a uint64 with the same ordering.
+func {{lowerFirst .Func}}Float64Key(f float64) uint64 {
+	k := math.Float64bits(f)
+	return k ^ (uint64(int64(k)>>63) | 1<<63)
+}
+{{- end}}
+`))
+
+var testTemplate = template.Must(template.New("test").Funcs(funcs).Parse(`// Code generated by radixgen -type={{.Type}} -fields={{.Args}}; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"cmp"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func Test{{upperFirst .Func}}(t *testing.T) {
+	for _, size := range []int{0, 1, 2, 10, 1000, 100_000} {
+		data := make([]{{.Type}}, size)
+		for i := range data {
+{{- range .Fields}}
+			data[i].{{.Name}} = {{randExpr .}}
+{{- end}}
+		}
+
+		want := append([]{{.Type}}{}, data...)
+		slices.SortStableFunc(want, func(a, b {{.Type}}) int {
+{{- range .Fields}}
+{{- if .Desc}}
+			if c := cmp.Compare(b.{{.Name}}, a.{{.Name}}); c != 0 {
+{{- else}}
+			if c := cmp.Compare(a.{{.Name}}, b.{{.Name}}); c != 0 {
+{{- end}}
+				return c
+			}
+{{- end}}
+			return 0
+		})
+
+		buf := make([]{{.Type}}, len(data))
+		if err := {{.Func}}(data, buf); err != nil {
+			t.Fatalf("{{.Func}} failed: %v", err)
+		}
+
+		if !reflect.DeepEqual(want, data) {
+			t.Errorf("{{.Func}}: size %d: result differs from slices.SortStableFunc", size)
+		}
+	}
+}
+`))
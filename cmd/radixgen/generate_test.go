@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := parseFields("Zone, -Temp,Seq")
+	if err != nil {
+		t.Fatalf("parseFields failed: %v", err)
+	}
+
+	want := []keyField{{Name: "Zone"}, {Name: "Temp", Desc: true}, {Name: "Seq"}}
+	if len(fields) != len(want) {
+		t.Fatalf("parseFields: got %d fields, want %d", len(fields), len(want))
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("parseFields: field %d = %+v, want %+v", i, fields[i], want[i])
+		}
+	}
+
+	if _, err := parseFields("Zone,,Seq"); err == nil {
+		t.Errorf("parseFields: expected error for empty field name")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	_, files, err := loadPackage("testdata", nil)
+	if err != nil {
+		t.Fatalf("loadPackage failed: %v", err)
+	}
+
+	fields := []keyField{{Name: "Temp"}, {Name: "Zone"}}
+	if err := resolve(files, "Reading", fields); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if fields[0].Type != "Celsius" || fields[0].Basic != "float64" {
+		t.Errorf("resolve: Temp = %+v, want type Celsius with basic float64", fields[0])
+	}
+	if fields[1].Type != "int8" || fields[1].Basic != "int8" {
+		t.Errorf("resolve: Zone = %+v, want int8", fields[1])
+	}
+
+	errorCases := []struct {
+		typeName string
+		field    string
+	}{
+		{typeName: "Missing", field: "Zone"},
+		{typeName: "Celsius", field: "Zone"},
+		{typeName: "Reading", field: "Height"},
+		{typeName: "Reading", field: "Sensor"},
+	}
+	for _, tc := range errorCases {
+		if err := resolve(files, tc.typeName, []keyField{{Name: tc.field}}); err == nil {
+			t.Errorf("resolve(%s, %s): expected error", tc.typeName, tc.field)
+		}
+	}
+}
+
+// TestGeneratedCode generates sorters for the testdata package into a
+// temporary module and runs the generated tests against this module.
+func TestGeneratedCode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping go test of generated code in short mode")
+	}
+
+	root, err := filepath.Abs(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	src, err := os.ReadFile(filepath.Join("testdata", "points.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mod := "module points\n\ngo 1.24\n\n" +
+		"require github.com/Kaidzen-62/radixsort v0.0.0\n\n" +
+		"replace github.com/Kaidzen-62/radixsort => " + root + "\n"
+
+	files := map[string][]byte{"points.go": src, "go.sum": sum, "go.mod": []byte(mod)}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := run(dir, "Reading", "Zone,-Temp,Seq", "", "", true); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if err := run(dir, "Reading", "-Delta,Epoch", "sortByDelta", "delta_radix.go", true); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+
+	for _, name := range []string{"reading_radix.go", "reading_radix_test.go", "delta_radix.go", "delta_radix_test.go"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("expected generated file %s: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command("go", "test", "-mod=mod", ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("go test of generated code failed: %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), "ok") {
+		t.Errorf("unexpected go test output:\n%s", out)
+	}
+}
@@ -0,0 +1,12 @@
+package points
+
+type Celsius float64
+
+type Reading struct {
+	Sensor string
+	Zone   int8
+	Temp   Celsius
+	Seq    uint32
+	Delta  float32
+	Epoch  int
+}
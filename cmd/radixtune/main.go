@@ -0,0 +1,95 @@
+// Radixtune measures the thresholds of the radixsort package on the local
+// machine and writes them to a tuning profile.
+//
+// The sorts switch between algorithms at input lengths that depend on the
+// cache sizes, memory bandwidth and core count of the CPU. Radixtune runs the
+// micro-benchmarks of radixsort.Calibrate and saves the result as JSON.
+//
+// Usage:
+//
+//	radixtune [-o radixsort-profile.json] [-max 8388608] [-v]
+//
+// -o names the output file; "-" writes the profile to standard output.
+// -max limits the longest input measured, and with it memory use and running
+// time; thresholds that need longer inputs keep their built-in values.
+// -v prints every measurement to standard error.
+//
+// Programs pick up the profile through the RADIXSORT_PROFILE environment
+// variable or by calling radixsort.LoadProfile:
+//
+//	radixtune -o /etc/radixsort.json
+//	RADIXSORT_PROFILE=/etc/radixsort.json ./server
+//
+// Run radixtune on an otherwise idle machine of the deployment hardware.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("radixtune: ")
+
+	output := flag.String("o", "radixsort-profile.json", "output file; - writes to standard output")
+	maxLen := flag.Int("max", 0, "longest input measured; default 8388608")
+	verbose := flag.Bool("v", false, "print every measurement to standard error")
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: radixtune [flags]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() > 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var progress io.Writer
+	if *verbose {
+		progress = os.Stderr
+	}
+	if err := run(ctx, *output, *maxLen, os.Stdout, progress); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run calibrates the package and writes the profile to output, or to stdout
+// if output is "-". Measurements are printed to progress if it is not nil.
+func run(ctx context.Context, output string, maxLen int, stdout, progress io.Writer) error {
+	opts := radixsort.CalibrateOptions{MaxLen: maxLen}
+	if progress != nil {
+		opts.Log = func(msg string) { fmt.Fprintln(progress, msg) }
+	}
+
+	p, err := radixsort.Calibrate(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if output == "-" {
+		content, err := json.MarshalIndent(p, "", "\t")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(stdout, "%s\n", content)
+		return err
+	}
+
+	if err := p.WriteFile(output); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(stdout, "wrote %s; load it with %s=%s\n", output, radixsort.ProfileEnv, output)
+	return err
+}
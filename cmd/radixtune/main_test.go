@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+func TestRun(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "profile.json")
+	var stdout, progress bytes.Buffer
+	if err := run(context.Background(), output, 1<<12, &stdout, &progress); err != nil {
+		t.Fatalf("run failed: %v", err)
+	}
+	if !strings.Contains(stdout.String(), radixsort.ProfileEnv+"="+output) {
+		t.Errorf("stdout = %q, want a hint to %s", stdout.String(), radixsort.ProfileEnv)
+	}
+	if !strings.Contains(progress.String(), "small uint64") {
+		t.Errorf("progress = %q, want the small-sort measurements", progress.String())
+	}
+
+	p, err := radixsort.ReadProfile(output)
+	if err != nil {
+		t.Fatalf("ReadProfile failed: %v", err)
+	}
+	if p.SmallSortMax64 <= 0 || p.Machine == "" {
+		t.Errorf("profile = %+v, want measured small-sort thresholds", p)
+	}
+
+	stdout.Reset()
+	if err := run(context.Background(), "-", 1<<12, &stdout, nil); err != nil {
+		t.Fatalf("run to stdout failed: %v", err)
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &p); err != nil {
+		t.Errorf("stdout is not a profile: %v", err)
+	}
+}
+
+func TestRunCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	output := filepath.Join(t.TempDir(), "profile.json")
+	if err := run(ctx, output, 1<<12, &bytes.Buffer{}, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,242 @@
+package radixsort
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/sagernet/sing/common/x/constraints"
+)
+
+// contextChunkLen is the number of elements processed between two checks of
+// the context. A chunk takes well under a millisecond, which bounds the
+// latency of a cancellation, and is long enough that the checks and progress
+// reports cost nothing measurable.
+const contextChunkLen = 1 << 16
+
+// Progress describes how far a sort started with one of the Context
+// functions, such as [Uint64Context], has advanced.
+type Progress struct {
+	// Pass is the index of the current pass over the data. Pass 0 counts
+	// the digits; every following pass moves the elements by one digit.
+	Pass int
+	// Passes is the number of passes of the sort. It is an upper bound
+	// during pass 0 and exact afterwards, when the digits that need no pass
+	// are known.
+	Passes int
+	// Processed is the number of elements processed in the current pass.
+	Processed int
+	// Total is the number of elements being sorted.
+	Total int
+}
+
+// ProgressFunc receives the progress of a sort after every chunk of
+// elements. It is called on the goroutine of the sort and should return
+// quickly.
+type ProgressFunc func(p Progress)
+
+// Uint64Context sorts a slice of uint64 values in ascending order like
+// [Uint64], but can be canceled through ctx.
+//
+// The context is checked between passes and between chunks of a pass. Once
+// it is done, the sort stops and returns ctx.Err(). The data then holds
+// a permutation of its original elements, partially sorted. If progress is
+// not nil, it is called after every chunk.
+//
+// Unlike Uint64, the Context functions need a buffer at least as long as the
+// data and return ErrInvalidBufferSize otherwise.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(ctx, time.Second)
+//	defer cancel()
+//	err := radixsort.Uint64Context(ctx, data, buf, nil)
+//	if errors.Is(err, context.DeadlineExceeded) {
+//	    // data is only partially sorted
+//	}
+func Uint64Context(ctx context.Context, data, buf []uint64, progress ProgressFunc) error {
+	return radixContext(ctx, data, buf, 0, progress)
+}
+
+// Uint32Context sorts a slice of uint32 values like [Uint32], but can be
+// canceled through ctx. See [Uint64Context] for details.
+func Uint32Context(ctx context.Context, data, buf []uint32, progress ProgressFunc) error {
+	return radixContext(ctx, data, buf, 0, progress)
+}
+
+// Int64Context sorts a slice of int64 values like [Int64], but can be
+// canceled through ctx. See [Uint64Context] for details.
+func Int64Context(ctx context.Context, data []int64, buf []uint64, progress ProgressFunc) error {
+	unsignedData := *(*[]uint64)(unsafe.Pointer(&data))
+	return radixContext(ctx, unsignedData, buf, 1<<63, progress)
+}
+
+// Int32Context sorts a slice of int32 values like [Int32], but can be
+// canceled through ctx. See [Uint64Context] for details.
+func Int32Context(ctx context.Context, data []int32, buf []uint32, progress ProgressFunc) error {
+	unsignedData := *(*[]uint32)(unsafe.Pointer(&data))
+	return radixContext(ctx, unsignedData, buf, 1<<31, progress)
+}
+
+// GenericContext sorts a slice of elements by a numeric key like [Generic],
+// but can be canceled through ctx. See [Uint64Context] for details.
+//
+// The sort is stable. If it is canceled, equal elements may have changed
+// their order.
+func GenericContext[E any, N ConstraintNumbers](ctx context.Context, data, buf []E, key func(a E) N, progress ProgressFunc) error {
+	var keyZeroValue N
+	digits := int(unsafe.Sizeof(keyZeroValue))
+	return radixContextFunc(ctx, data, buf, digits, orderedKeyFunc(key), progress)
+}
+
+// radixContext performs an LSD radix sort with the 8-bit digits of v^flip
+// that checks ctx after every chunk of contextChunkLen elements. Digits whose
+// elements all fall into one bucket are skipped.
+//
+// A scatter pass only reads its source, so on cancellation the source of the
+// current pass is a complete permutation of the input and is copied back into
+// data if needed.
+func radixContext[T constraints.Unsigned](ctx context.Context, data, buf []T, flip T, progress ProgressFunc) error {
+	if len(buf) < len(data) {
+		return ErrInvalidBufferSize
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	digits := int(unsafe.Sizeof(flip))
+	p := Progress{Passes: 1 + digits, Total: len(data)}
+
+	offsets := [8][256]uint{}
+	for lo := 0; lo < len(data); lo += contextChunkLen {
+		for _, v := range data[lo:min(lo+contextChunkLen, len(data))] {
+			v ^= flip
+			for d := range digits {
+				offsets[d][uint8(v>>(d*8))]++
+			}
+		}
+		if err := p.advance(ctx, lo, progress); err != nil {
+			return err
+		}
+	}
+
+	active := contextOffsets(&offsets, digits, len(data))
+	p.Passes = 1 + len(active)
+
+	src, dst := data, buf[:len(data)]
+	for _, d := range active {
+		p.Pass++
+		p.Processed = 0
+		o := &offsets[d]
+		shift := uint(d * 8)
+		for lo := 0; lo < len(src); lo += contextChunkLen {
+			for _, v := range src[lo:min(lo+contextChunkLen, len(src))] {
+				b := uint8((v ^ flip) >> shift)
+				dst[o[b]] = v
+				o[b]++
+			}
+			if err := p.advance(ctx, lo, progress); err != nil {
+				if lo+contextChunkLen >= len(src) {
+					// The pass has completed.
+					src = dst
+				}
+				copyBack(data, src)
+				return err
+			}
+		}
+		src, dst = dst, src
+	}
+
+	copyBack(data, src)
+	return nil
+}
+
+// radixContextFunc is radixContext for elements sorted by the low digits
+// bytes of key.
+func radixContextFunc[E any](ctx context.Context, data, buf []E, digits int, key func(a E) uint64, progress ProgressFunc) error {
+	if len(buf) < len(data) {
+		return ErrInvalidBufferSize
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	p := Progress{Passes: 1 + digits, Total: len(data)}
+
+	offsets := [8][256]uint{}
+	for lo := 0; lo < len(data); lo += contextChunkLen {
+		for _, e := range data[lo:min(lo+contextChunkLen, len(data))] {
+			k := key(e)
+			for d := range digits {
+				offsets[d][uint8(k>>(d*8))]++
+			}
+		}
+		if err := p.advance(ctx, lo, progress); err != nil {
+			return err
+		}
+	}
+
+	active := contextOffsets(&offsets, digits, len(data))
+	p.Passes = 1 + len(active)
+
+	src, dst := data, buf[:len(data)]
+	for _, d := range active {
+		p.Pass++
+		p.Processed = 0
+		o := &offsets[d]
+		shift := uint(d * 8)
+		for lo := 0; lo < len(src); lo += contextChunkLen {
+			for _, e := range src[lo:min(lo+contextChunkLen, len(src))] {
+				b := uint8(key(e) >> shift)
+				dst[o[b]] = e
+				o[b]++
+			}
+			if err := p.advance(ctx, lo, progress); err != nil {
+				if lo+contextChunkLen >= len(src) {
+					src = dst
+				}
+				copyBack(data, src)
+				return err
+			}
+		}
+		src, dst = dst, src
+	}
+
+	copyBack(data, src)
+	return nil
+}
+
+// advance records that the chunk starting at lo has been processed, reports
+// the progress and returns the error of ctx, if any.
+func (p *Progress) advance(ctx context.Context, lo int, progress ProgressFunc) error {
+	p.Processed = min(lo+contextChunkLen, p.Total)
+	if progress != nil {
+		progress(*p)
+	}
+	return ctx.Err()
+}
+
+// contextOffsets converts the digit histograms in offsets into start offsets
+// and returns the digits that need a pass, i.e. whose n elements do not all
+// fall into one bucket.
+func contextOffsets(offsets *[8][256]uint, digits, n int) []int {
+	active := make([]int, 0, digits)
+	for d := range digits {
+		acc := uint(0)
+		constant := false
+		for b, c := range offsets[d] {
+			constant = constant || c == uint(n)
+			offsets[d][b], acc = acc, acc+c
+		}
+		if !constant {
+			active = append(active, d)
+		}
+	}
+	return active
+}
+
+// copyBack copies the elements of src into data unless src is data.
+func copyBack[E any](data, src []E) {
+	if len(data) > 0 && &src[0] != &data[0] {
+		copy(data, src)
+	}
+}
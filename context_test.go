@@ -0,0 +1,135 @@
+package radixsort_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+func TestContextSorts(t *testing.T) {
+	const size = 300_000
+	input := make([]int64, size)
+	for i := range input {
+		input[i] = rand.Int63() - 1<<62
+	}
+	want := slices.Clone(input)
+	slices.Sort(want)
+
+	var reports []radixsort.Progress
+	record := func(p radixsort.Progress) { reports = append(reports, p) }
+
+	data := slices.Clone(input)
+	if err := radixsort.Int64Context(context.Background(), data, make([]uint64, size), record); err != nil {
+		t.Fatalf("Int64Context failed: %v", err)
+	}
+	if !slices.Equal(want, data) {
+		t.Error("Int64Context failed to sort data correctly")
+	}
+
+	last := reports[len(reports)-1]
+	if last.Pass != last.Passes-1 || last.Processed != size || last.Total != size {
+		t.Errorf("last progress = %+v, want the end of the last pass", last)
+	}
+	for i := 1; i < len(reports); i++ {
+		prev, cur := reports[i-1], reports[i]
+		if cur.Pass < prev.Pass || cur.Pass == prev.Pass && cur.Processed <= prev.Processed {
+			t.Fatalf("progress went back from %+v to %+v", prev, cur)
+		}
+	}
+
+	unsigned := make([]uint32, size)
+	for i := range unsigned {
+		unsigned[i] = rand.Uint32()
+	}
+	if err := radixsort.Uint32Context(context.Background(), unsigned, make([]uint32, size), nil); err != nil {
+		t.Fatalf("Uint32Context failed: %v", err)
+	}
+	if !slices.IsSorted(unsigned) {
+		t.Error("Uint32Context failed to sort data correctly")
+	}
+}
+
+func TestContextCancel(t *testing.T) {
+	// A few chunks per pass; the last one is shorter.
+	const size = 200_000
+	input := make([]uint64, size)
+	for i := range input {
+		input[i] = rand.Uint64()
+	}
+	want := slices.Clone(input)
+	slices.Sort(want)
+
+	// Cancel after every possible number of chunks, including in the
+	// histogram pass and right at the end of a scatter pass.
+	for stop := 1; ; stop++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		data := slices.Clone(input)
+		err := radixsort.Uint64Context(ctx, data, make([]uint64, size), func(radixsort.Progress) {
+			calls++
+			if calls == stop {
+				cancel()
+			}
+		})
+		cancel()
+
+		if err == nil {
+			if !slices.Equal(want, data) {
+				t.Error("Uint64Context failed to sort data correctly")
+			}
+			break
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("stop %d: error = %v, want %v", stop, err, context.Canceled)
+		}
+		slices.Sort(data)
+		if !slices.Equal(want, data) {
+			t.Fatalf("stop %d: data is not a permutation of the input", stop)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	data := slices.Clone(input)
+	if err := radixsort.Uint64Context(ctx, data, make([]uint64, size), nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("error = %v, want %v", err, context.Canceled)
+	}
+	if !slices.Equal(input, data) {
+		t.Error("Uint64Context modified data despite a canceled context")
+	}
+}
+
+func TestContextErrors(t *testing.T) {
+	data := []uint64{3, 2, 1}
+	err := radixsort.Uint64Context(context.Background(), data, make([]uint64, 2), nil)
+	if !errors.Is(err, radixsort.ErrInvalidBufferSize) {
+		t.Errorf("error = %v, want %v", err, radixsort.ErrInvalidBufferSize)
+	}
+}
+
+func TestGenericContext(t *testing.T) {
+	type item struct {
+		key float32
+		seq int
+	}
+	const size = 200_000
+	data := make([]item, size)
+	for i := range data {
+		data[i] = item{key: float32(rand.Intn(1000)-500) / 8, seq: i}
+	}
+	want := slices.Clone(data)
+	slices.SortStableFunc(want, func(a, b item) int { return cmp.Compare(a.key, b.key) })
+
+	err := radixsort.GenericContext(context.Background(), data, make([]item, size), func(e item) float32 { return e.key }, nil)
+	if err != nil {
+		t.Fatalf("GenericContext failed: %v", err)
+	}
+	if !slices.Equal(want, data) {
+		t.Error("GenericContext failed to sort data stably")
+	}
+}
@@ -0,0 +1,141 @@
+package radixsort
+
+import (
+	"slices"
+	"unsafe"
+
+	"github.com/sagernet/sing/common/x/constraints"
+)
+
+// countingMinLen is the shortest input whose value range is scanned to
+// decide whether to sort it by counting.
+const countingMinLen = 1 << 12
+
+// countingDensity is the minimum number of elements per value of the range
+// for which counting beats the radix passes. Counting writes the output
+// straight from the counters, but must scan one counter per possible value;
+// with 16-bit values it was faster from about four elements per counter.
+const countingDensity = 4
+
+// countingSort8 sorts 8-bit values by counting them and writing every value
+// back as many times as it occurs. Unlike a radix pass it moves no elements,
+// so it needs no buffer.
+func countingSort8[T uint8 | int8](data []T) {
+	// The smallest value of T is 0 for uint8 and -128 for int8.
+	minimum := T(0)
+	if one := T(1); one<<7 < 0 {
+		minimum = one << 7
+	}
+
+	var counts [256]uint
+	countFill(data, minimum, counts[:])
+}
+
+// countingMaxRange is the largest value range sorted by counting. The
+// counters of a wider range would no longer fit into the L2 cache.
+const countingMaxRange = 1 << 16
+
+// countingSortRange sorts integers by counting if the input is long enough
+// for the range of its values, and reports whether data is sorted. Ranges of
+// up to 256 values are counted on the stack, wider ranges in spare, usually
+// the [counters] of the caller's buffer; if spare is too short, the input is
+// left to the radix passes. Since counting needs four elements per value,
+// a buffer as long as data holds enough counters unless it is not aligned to
+// a word.
+func countingSortRange[T constraints.Integer](data []T, spare []uint) bool {
+	if len(data) < countingMinLen {
+		return false
+	}
+
+	// The scan also detects sorted and reversed input, which needs no
+	// counting at all: equal integers are indistinguishable, so reversing
+	// descending values sorts them.
+	minimum, maximum := data[0], data[0]
+	ascending, descending, prev := true, true, data[0]
+	for _, v := range data {
+		minimum = min(minimum, v)
+		maximum = max(maximum, v)
+		ascending = ascending && v >= prev
+		descending = descending && v <= prev
+		prev = v
+	}
+	switch {
+	case ascending:
+		return true
+	case descending:
+		slices.Reverse(data)
+		return true
+	}
+	// The difference of the sign-extended values is exact for all widths.
+	span := uint64(maximum) - uint64(minimum)
+	if span >= countingMaxRange || uint64(len(data)) < countingDensity*(span+1) {
+		return false
+	}
+
+	var small [256]uint
+	counts := small[:]
+	if span >= uint64(len(small)) {
+		counts = spare
+		if uint64(len(counts)) <= span {
+			return false
+		}
+		clear(counts[:span+1])
+	}
+	countFill(data, minimum, counts[:span+1])
+	return true
+}
+
+// counters returns the largest slice of counters that fits into the memory of
+// buf, which holds plain integers and can therefore be reinterpreted. The
+// counters are not cleared.
+func counters[B constraints.Integer](buf []B) []uint {
+	if len(buf) == 0 {
+		return nil
+	}
+
+	// Skip the bytes up to the alignment of uint.
+	p := unsafe.Pointer(unsafe.SliceData(buf))
+	skip := -uintptr(p) & (unsafe.Alignof(uint(0)) - 1)
+	size := uintptr(len(buf)) * unsafe.Sizeof(buf[0])
+	if size <= skip {
+		return nil
+	}
+	return unsafe.Slice((*uint)(unsafe.Add(p, skip)), (size-skip)/unsafe.Sizeof(uint(0)))
+}
+
+// countFill counts the values of data, which must lie in
+// [minimum, minimum+len(counts)), and overwrites data with them in order.
+func countFill[T constraints.Integer](data []T, minimum T, counts []uint) {
+	for _, v := range data {
+		counts[int(v)-int(minimum)]++
+	}
+
+	i := uint(0)
+	for k, c := range counts {
+		v := minimum + T(k)
+		run := data[i : i+c]
+		for j := range run {
+			run[j] = v
+		}
+		i += c
+	}
+}
+
+// countFillWrapped is countFill for unsigned integers whose values lie in
+// [base, base+len(counts)) modulo the width of T. It sorts signed integers in
+// their unsigned representation, whose range wraps around at the sign bit.
+func countFillWrapped[T constraints.Unsigned](data []T, base T, counts []uint) {
+	for _, v := range data {
+		counts[v-base]++
+	}
+
+	i := uint(0)
+	for k, c := range counts {
+		v := base + T(k)
+		run := data[i : i+c]
+		for j := range run {
+			run[j] = v
+		}
+		i += c
+	}
+}
@@ -0,0 +1,102 @@
+package radixsort_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"golang.org/x/exp/constraints"
+)
+
+// denseInput returns size values drawn from [lo, lo+span).
+func denseInput[T constraints.Integer](size int, lo, span int) []T {
+	data := make([]T, size)
+	for i := range data {
+		data[i] = T(lo + rand.Intn(span))
+	}
+	return data
+}
+
+func testCounting[T constraints.Integer, B constraints.Unsigned](t *testing.T, sortFunc func([]T, []B) error, inPlace func([]T), sortFuncName string, lo, span int) {
+	for _, size := range []int{1 << 12, 1 << 18} {
+		input := denseInput[T](size, lo, span)
+		want := slices.Clone(input)
+		slices.Sort(want)
+
+		data := slices.Clone(input)
+		if err := sortFunc(data, make([]B, size)); err != nil {
+			t.Fatalf("%s(%d elements) failed: %v", sortFuncName, size, err)
+		}
+		if !slices.Equal(want, data) {
+			t.Errorf("%s(%d elements in [%d, %d)) failed to sort data correctly", sortFuncName, size, lo, lo+span)
+		}
+
+		data = slices.Clone(input)
+		inPlace(data)
+		if !slices.Equal(want, data) {
+			t.Errorf("InPlace%s(%d elements in [%d, %d)) failed to sort data correctly", sortFuncName, size, lo, lo+span)
+		}
+	}
+}
+
+func TestCounting(t *testing.T) {
+	testCounting(t, radixsort.Uint8, radixsort.InPlaceUint8, "Uint8", 0, 256)
+	testCounting(t, radixsort.Int8, radixsort.InPlaceInt8, "Int8", -128, 256)
+	testCounting(t, radixsort.Uint16, radixsort.InPlaceUint16, "Uint16", 40_000, 1000)
+	testCounting(t, radixsort.Uint16, radixsort.InPlaceUint16, "Uint16", 0, 1<<16)
+	testCounting(t, radixsort.Int16, radixsort.InPlaceInt16, "Int16", -500, 1000)
+	testCounting(t, radixsort.Int16, radixsort.InPlaceInt16, "Int16", -1<<15, 1<<16)
+}
+
+func TestCountingLeavesBufferUntouched(t *testing.T) {
+	data := denseInput[uint8](1000, 0, 256)
+	buf := make([]uint8, len(data))
+	for i := range buf {
+		buf[i] = 0xaa
+	}
+
+	if err := radixsort.Uint8(data, buf); err != nil {
+		t.Fatalf("Uint8 failed: %v", err)
+	}
+	if !slices.IsSorted(data) {
+		t.Errorf("Uint8 failed to sort data correctly")
+	}
+	if slices.ContainsFunc(buf, func(b uint8) bool { return b != 0xaa }) {
+		t.Errorf("Uint8 wrote to the buffer")
+	}
+}
+
+func TestCountingAllocs(t *testing.T) {
+	const size = 1 << 18
+	input := denseInput[uint16](size, 0, 1<<16)
+	data := make([]uint16, size)
+	// The buffer is longer than needed so that a slice of it can start at an
+	// address that is not aligned for the counters.
+	buf := make([]uint16, size+1)
+
+	tests := []struct {
+		name string
+		sort func()
+	}{
+		{name: "Uint16", sort: func() { _ = radixsort.Uint16(data, buf) }},
+		{name: "Uint16 unaligned buffer", sort: func() { _ = radixsort.Uint16(data, buf[1:]) }},
+		{name: "UnstableUint16", sort: func() { radixsort.UnstableUint16(data, buf) }},
+		{name: "InPlaceUint16", sort: func() { radixsort.InPlaceUint16(data) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(5, func() {
+				copy(data, input)
+				tt.sort()
+			})
+			if allocs != 0 {
+				t.Errorf("%s made %v allocations, want 0", tt.name, allocs)
+			}
+			if !slices.IsSorted(data) {
+				t.Errorf("%s failed to sort data correctly", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package radixsort
+
+import (
+	"math/bits"
+
+	"github.com/sagernet/sing/common/x/constraints"
+)
+
+// wideDigitMinLen is the shortest input of 32-bit keys sorted with 11-bit
+// digits.
+//
+// An 11-bit pass clears and scans 2048 counters and scatters into 2048 output
+// streams instead of 256, which is amortized only on long inputs. Since the
+// 8-bit scatter loops compute each bucket index once, they keep up with
+// 11-bit digits that save a quarter of the passes up to about a million
+// elements (see BenchmarkDigits). 64-bit keys always use 8-bit digits: long
+// inputs are partitioned into cache-sized buckets first (see hybrid64), and
+// their scatter passes are write-combined (see scatterCombined), which alone
+// keeps them within a few percent of 11-bit digits.
+//
+// 16-bit digits were measured as well (see BenchmarkWideDigits). Two 16-bit
+// passes over uint32 keys tie with or lose to the 8- and 11-bit kernels, as
+// 65536 counters and output streams overflow the caches, and their counters
+// would need memory beyond the buffer. For 16-bit values the single 16-bit
+// pass is a counting sort, which Uint16 runs on dense inputs, see
+// countingSortRange.
+var wideDigitMinLen = newThreshold(1 << 22)
+
+// keyRangeMinLen is the shortest input for which the kernels scan the key
+// range before sorting. The scan costs a read pass, which is repaid only if it
+// saves a scatter pass.
+const keyRangeMinLen = 1 << 16
+
+// Digit layouts chosen by planDigits.
+const (
+	// digitsPlain sorts the keys by their bytes, skipping constant ones.
+	digitsPlain = iota
+	// digitsBase sorts the bytes of v-min.
+	digitsBase
+	// digitsWide sorts the 11-bit digits of (v-min)>>lo.
+	digitsWide
+)
+
+// radix32 sorts data with the digit layout that needs the fewest passes over
+// the key range of the input, see [planDigits].
+// The buffer length must be at least as large as data.
+func radix32(data, buf []uint32) error {
+	if len(data) < keyRangeMinLen || len(buf) < len(data) {
+		return radix32b8(data, buf)
+	}
+
+	minimum, maximum, diff := keyRange(data)
+	wide := len(data) >= wideDigitMinLen.get()
+	switch planDigits(wide, uint64(maximum-minimum), uint64(diff)) {
+	case digitsWide:
+		radix32b11(data, buf, minimum, uint(bits.TrailingZeros32(diff)))
+		return nil
+	case digitsBase:
+		return radix32b8Base(data, buf, minimum)
+	}
+	return radix32b8(data, buf)
+}
+
+// radix64 sorts data with 8-bit digits, realigned to the key range of the
+// input if that saves passes, see [planDigits]. Inputs larger than the cache
+// are first partitioned by their most significant varying byte, see
+// [hybrid64].
+// The buffer length must be at least as large as data.
+func radix64(data, buf []uint64) error {
+	if len(data) < keyRangeMinLen || len(buf) < len(data) {
+		return radix64b8(data, buf)
+	}
+
+	minimum, maximum, diff := keyRange(data)
+	// The write-combined 8-bit scatter matches 11-bit digits on 64-bit keys.
+	base, keys := uint64(0), diff
+	if planDigits(false, maximum-minimum, diff) == digitsBase {
+		base, keys = minimum, maximum-minimum
+	}
+	if len(data) >= hybridMinLen.get() {
+		hybrid64(data, buf, base, topShift(keys), 1)
+		return nil
+	}
+	return radix64b8Base(data, buf, base)
+}
+
+// keyRange returns the minimum and maximum of data and the bits that are not
+// identical across all of its elements.
+func keyRange[T constraints.Unsigned](data []T) (minimum, maximum, diff T) {
+	or, and := T(0), ^T(0)
+	minimum, maximum = ^T(0), 0
+	for _, v := range data {
+		or |= v
+		and &= v
+		minimum = min(minimum, v)
+		maximum = max(maximum, v)
+	}
+	return minimum, maximum, or ^ and
+}
+
+// planDigits picks the digit layout for keys given their range max-min and
+// the bits diff that vary across them; wide allows 11-bit digits.
+//
+// The plain 8-bit kernels skip the bytes that are constant, which costs one
+// pass for every byte that diff touches. Keys of a narrow range that crosses
+// a byte boundary, such as timestamps of a short window, touch one byte more
+// than their width needs; subtracting the minimum realigns the digits to the
+// range. Both variants are compared with 11-bit digits over the range
+// shifted down by the constant low bits, which win on long inputs. Ties go to
+// the plain layout.
+func planDigits(wide bool, keyRange, diff uint64) int {
+	if diff == 0 {
+		return digitsPlain
+	}
+
+	lo := uint(bits.TrailingZeros64(diff))
+	passes := uint(varyingBytes(diff))
+
+	// The low lo bits of v-min are zero, and so are the bytes below lo/8.
+	rangeBits := uint(bits.Len64(keyRange))
+	passesBase := (rangeBits+7)/8 - lo/8
+	passesWide := (rangeBits - lo + 10) / 11
+
+	layout := digitsPlain
+	if passesBase < passes {
+		layout, passes = digitsBase, passesBase
+	}
+	if wide && passesWide < passes {
+		layout = digitsWide
+	}
+	return layout
+}
+
+// varyingBytes returns the number of bytes in which diff has bits set.
+func varyingBytes(diff uint64) int {
+	n := 0
+	for ; diff != 0; diff >>= 8 {
+		if uint8(diff) != 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Uint32Bits sorts a slice of uint32 values in ascending order, given a hint
+// that the values fit into their low bits bits, i.e. v < 1<<bits.
+//
+// The hint spares the scan of the key range that [Uint32] performs on long
+// inputs to choose its digits: with it, the sort goes straight to the layout
+// with the fewest passes over the low bits bits. The hint only affects speed.
+// Values that do not fit are still sorted correctly, at the cost of extra
+// passes.
+//
+// See [Uint32] for the description of buf and the returned errors.
+//
+// Example:
+//
+//	// Thread IDs below 2^22.
+//	err := Uint32Bits(ids, buf, 22)
+func Uint32Bits(data, buf []uint32, bits uint) error {
+	if len(buf) < len(data) || bits >= 32 {
+		return Uint32(data, buf)
+	}
+	if isSmall(len(data), 4) {
+		insertionSort(data)
+		return nil
+	}
+	if sortSampled(data, buf, 0) {
+		return nil
+	}
+
+	wide := len(data) >= wideDigitMinLen.get()
+	if planDigits(wide, 1<<bits-1, 1<<bits-1) == digitsWide {
+		radix32b11(data, buf, 0, 0)
+		return nil
+	}
+	return radix32b8(data, buf)
+}
+
+// radix32b11 performs an LSD radix sort with the 11-bit digits of
+// (v-base)>>lo, where base must not be greater than any element and the bits
+// below lo must be identical across all elements.
+// Presorted input is detected as in the 8-bit kernels.
+// The buffer length must be at least as large as data.
+func radix32b11(data, buf []uint32, base uint32, lo uint) {
+	descents := 0
+	prev := uint32(0)
+
+	// offsets[d][b] stores the counters, and later the offsets, of bucket b
+	// of digit d. Digits above bit 31 are always zero and skipped below.
+	offsets := [3][2048]uint{}
+	for _, v := range data {
+		if v < prev {
+			descents++
+		}
+		prev = v
+		v = (v - base) >> lo
+		offsets[0][v&0x7ff]++
+		offsets[1][(v>>11)&0x7ff]++
+		offsets[2][(v>>22)&0x7ff]++
+	}
+
+	if sortPresorted(data, buf, descents, mergeRunsMax32) {
+		return
+	}
+
+	scatterWide(data, buf, offsets[:], base, lo)
+}
+
+// scatterWide runs the scatter passes of an 11-bit LSD radix sort from the
+// digit histograms. Digits whose elements all fall into one bucket are
+// skipped, so the sort stays stable and costs no pass for constant digits.
+func scatterWide(data, buf []uint32, offsets [][2048]uint, base uint32, lo uint) {
+	n := uint(len(data))
+	src, dst := data, buf[:len(data)]
+	swaps := 0
+	for d := range offsets {
+		shift := lo + uint(d)*11
+		if offsets[d][((src[0]-base)>>shift)&0x7ff] == n {
+			continue
+		}
+
+		acc := uint(0)
+		for b, c := range offsets[d] {
+			offsets[d][b], acc = acc, acc+c
+		}
+
+		o := &offsets[d]
+		for _, v := range src {
+			b := ((v - base) >> shift) & 0x7ff
+			dst[o[b]] = v
+			o[b]++
+		}
+		src, dst = dst, src
+		swaps++
+	}
+
+	if swaps&1 == 1 {
+		copy(data, src)
+	}
+}
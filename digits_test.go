@@ -0,0 +1,117 @@
+package radixsort_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+// keyRanges describe inputs whose varying bits cover different ranges, so
+// that all digit layouts and the skipping of constant digits are exercised.
+var keyRanges = []struct {
+	name string
+	base uint64
+	lo   uint
+	span uint
+}{
+	{name: "full range", lo: 0, span: 64},
+	{name: "low 11 bits", lo: 0, span: 11},
+	{name: "16 bits", lo: 0, span: 16},
+	{name: "22 bits at offset 5", lo: 5, span: 22},
+	{name: "30 bits at offset 30", lo: 30, span: 30},
+	{name: "42 bits above a large base", base: 1_760_000_000_000_000_000, span: 42},
+	{name: "20 bits above a base crossing a byte", base: 0xff_f800, span: 20},
+}
+
+func keyRangeInput(size int, base uint64, lo, span uint) []uint64 {
+	data := make([]uint64, size)
+	for i := range data {
+		v := rand.Uint64()
+		if span < 64 {
+			v &= 1<<span - 1
+		}
+		// Set a constant pattern in the bits below the varying range.
+		data[i] = base + (v<<lo | 0x5555_5555_5555_5555&(1<<lo-1))
+	}
+	return data
+}
+
+func TestDigitLayouts(t *testing.T) {
+	const size = 1 << 16
+
+	for _, wideMinLen := range []int{size, 1 << 30} {
+		restore := radixsort.SetWideDigitMinLen(wideMinLen)
+		defer restore()
+
+		for _, tt := range keyRanges {
+			t.Run(fmt.Sprintf("%s/wide from %d", tt.name, wideMinLen), func(t *testing.T) {
+				data64 := keyRangeInput(size, tt.base, tt.lo, tt.span)
+				data32 := make([]uint32, size)
+				for i, v := range data64 {
+					data32[i] = uint32(v)
+				}
+
+				want64 := slices.Clone(data64)
+				slices.Sort(want64)
+				if err := radixsort.Uint64(data64, make([]uint64, size)); err != nil {
+					t.Fatalf("Uint64 failed: %v", err)
+				}
+				if !slices.Equal(want64, data64) {
+					t.Errorf("Uint64 failed to sort data correctly")
+				}
+
+				want32 := slices.Clone(data32)
+				slices.Sort(want32)
+				if err := radixsort.Uint32(data32, make([]uint32, size)); err != nil {
+					t.Fatalf("Uint32 failed: %v", err)
+				}
+				if !slices.Equal(want32, data32) {
+					t.Errorf("Uint32 failed to sort data correctly")
+				}
+			})
+		}
+	}
+}
+
+func TestBitsHint(t *testing.T) {
+	const size = 1 << 16
+
+	restore := radixsort.SetWideDigitMinLen(size)
+	defer restore()
+
+	tests := []struct {
+		name     string
+		size     int
+		dataBits uint
+		hintBits uint
+	}{
+		{name: "exact hint", size: size, dataBits: 22, hintBits: 22},
+		{name: "loose hint", size: size, dataBits: 12, hintBits: 30},
+		// A wrong hint costs speed, not correctness.
+		{name: "wrong hint", size: size, dataBits: 64, hintBits: 16},
+		{name: "full width", size: size, dataBits: 64, hintBits: 64},
+		// Few distinct values are detected by sampling.
+		{name: "few values", size: size, dataBits: 3, hintBits: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data32 := make([]uint32, tt.size)
+			for i, v := range keyRangeInput(tt.size, 0, 0, tt.dataBits) {
+				data32[i] = uint32(v)
+			}
+
+			want32 := slices.Clone(data32)
+			slices.Sort(want32)
+			if err := radixsort.Uint32Bits(data32, make([]uint32, tt.size), tt.hintBits); err != nil {
+				t.Fatalf("Uint32Bits failed: %v", err)
+			}
+			if !slices.Equal(want32, data32) {
+				t.Errorf("Uint32Bits failed to sort data correctly")
+			}
+		})
+	}
+}
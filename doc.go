@@ -9,20 +9,40 @@
 // fixed-width types, making it faster than comparison-based algorithms for
 // large datasets.
 //
-// # Features
-//
-//   - Stable sorting: preserves the relative order of equal elements
-//   - In-place sorting with a temporary buffer
-//   - Optimized for unsigned integers (uint8, uint16, uint32, uint64)
-//   - Support for signed integers (int8, int16, int32, int64)
-//   - Generic sorting for custom types with numeric keys
-//   - Automatic skip of redundant sorting passes
+// The sorts are stable LSD radix sorts with 8-bit digits, or 11-bit digits on
+// long 32-bit inputs when they save passes. The histogram pass skips digits
+// that are the same in all keys and finishes sorted, reversed and nearly
+// sorted input early. Long inputs are first scanned for their key range, so
+// that a narrow range costs only the passes it needs, and sampled to pick
+// counting, MSD or comparison sorts where they win, as for duplicates. Long
+// 64-bit inputs are partitioned into cache-sized buckets before the LSD
+// passes. The thresholds between these algorithms can be calibrated for the
+// local machine ([Calibrate], cmd/radixtune) and loaded from a profile
+// ([LoadProfile], [ProfileEnv]).
+//
+// Besides slices of integers ([Uint64], [Int64] and the other widths), the
+// package sorts custom types by a numeric key ([Generic]) or by struct fields
+// ([SortBy]), containers that implement [Keyed], columns ([Table]), many
+// slices in one call ([SegmentedUint32], [BatchUint64], [RowsUint64]), and
+// large inputs on several goroutines ([Parallel]). Sorts can be canceled
+// through a context ([Uint64Context]), and unstable variants
+// ([UnstableUint64], [InPlaceUint64]) trade stability for speed or memory.
 //
 // # Usage
 //
-// Each sorting function requires a temporary buffer of the same length as
-// the input data. This design avoids allocations during sorting and allows
-// buffer reuse across multiple sort operations.
+// Each sorting function takes a temporary buffer, normally of the same length
+// as the input data, and can reuse it across sort operations. The integer
+// sorts take all their scratch memory from the buffer, including the counters
+// of counting sort, and do not allocate. Functions that need more memory say
+// so in their documentation, such as [GenericCached] and [SortBy], which store
+// a key per element, and [Generic] for large elements. Where memory is
+// scarce, a shorter buffer down to [MinBufferSize] elements can be passed
+// instead.
+//
+// Callers that do not want to manage buffers can use a [Sorter], which keeps
+// pooled buffers per key width and is safe for concurrent use:
+//
+//	radixsort.DefaultSorter.Uint64(data)
 //
 // Basic example for uint64:
 //
@@ -52,17 +72,52 @@
 //	buf := make([]Item, len(items))
 //	err := radixsort.Generic(items, buf, func(i Item) float64 { return i.Score })
 //
+// When the key is expensive to compute, [GenericCached] extracts every key
+// once and sorts (key, index) pairs instead of the elements, allocating 32
+// bytes of temporary storage per element for the pairs. Generic sorts pairs
+// as well when that saves moving large elements over several passes.
+//
+// Containers that are not Go slices, such as ring buffers or memory-mapped
+// tables, can implement the [Keyed] interface and be sorted with [Sort].
+// Containers of large records can also implement [Mover], so that Sort moves
+// the records instead of swapping them.
+//
+// Column-oriented data is sorted with [Table], which computes one stable
+// permutation from the key columns and applies it to every registered column.
+//
+// When a second copy of the data does not fit in memory, the InPlace functions,
+// such as [InPlaceUint64] and [InPlaceGeneric], sort without a buffer using
+// American flag sort. They are not stable.
+//
+// Inputs of hundreds of millions of elements can be sorted on several cores
+// with the methods of [Parallel] and with [GenericParallel], which produce the
+// same stable result as the sequential sorts:
+//
+//	p := radixsort.Parallel{Workers: 32}
+//	err := p.Uint64(data, buf)
+//
+// Structs can also be sorted by field names or `radix` struct tags with
+// [SortBy], which builds the keys via reflection:
+//
+//	err := radixsort.SortBy(items, "Score,desc", "ID")
+//
 // # Performance Considerations
 //
 // Radix sort excels when:
 //   - Sorting large collections of fixed-width numbers
 //   - Stable ordering is required
 //
-// For small slices (< 1000 elements), standard library sort may be faster
-// due to lower constant factors.
+// Short inputs do not amortize the setup of the radix passes, so the entry
+// points sort them with insertion sort instead. The threshold depends on the
+// key width, from 32 elements for 8-bit keys to 128 elements for 64-bit keys.
+// Slightly longer slices of wide keys (up to a few hundred elements) may still
+// sort faster with the standard library.
 //
 // # Error Handling
 //
-// All functions return [ErrInvalidBufferSize] if the buffer is smaller than
-// the data slice. Ensure len(buf) >= len(data) before calling.
+// The sorts that use their buffer return [ErrInvalidBufferSize] if it is too
+// small; the 8-bit sorts and the Unstable functions accept any buffer.
+// A buffer with len(buf) >= len(data) is always large enough. The integer and
+// generic sorts also accept shorter buffers of at least [MinBufferSize]
+// elements and then sort within that memory, at some cost in speed.
 package radixsort
@@ -2,14 +2,17 @@ package radixsort
 
 import "errors"
 
-// ErrInvalidBufferSize is returned when the provided buffer slice is smaller
-// than the data slice to be sorted.
+// ErrInvalidBufferSize is returned when the provided buffer slice is too
+// small for the data slice to be sorted.
 //
 // All sorting functions in this package require a temporary buffer for
-// intermediate storage during the radix sort process. The buffer must have
-// a length greater than or equal to the data slice:
+// intermediate storage during the radix sort process. The plain and generic
+// sorts run fastest with a buffer at least as long as the data and accept
+// shorter buffers in a bounded memory mode (see [MinBufferSize]):
 //
-//	len(buf) >= len(data)
+//	len(buf) >= min(len(data), MinBufferSize)
+//
+// The segmented, batch and row sorts document their own requirements.
 //
 // This error indicates that the buffer is too small and the sorting
 // operation cannot proceed safely.
@@ -21,3 +24,31 @@ import "errors"
 //	err := radixsort.Uint64(data, buf)
 //	// err == radixsort.ErrInvalidBufferSize
 var ErrInvalidBufferSize = errors.New("buffer length is less than data length")
+
+// ErrUnsupportedKind is returned by [SortBy] when a key field has a kind that
+// cannot be converted into a radix key.
+//
+// Only bool, integer and floating-point fields can be used as sort keys.
+// Strings, slices, maps, structs and other composite kinds are rejected.
+// The returned error wraps ErrUnsupportedKind and names the offending field:
+//
+//	err := radixsort.SortBy(users, "Name")
+//	// errors.Is(err, radixsort.ErrUnsupportedKind) == true
+var ErrUnsupportedKind = errors.New("field kind is not supported as a sort key")
+
+// ErrInvalidSegments is returned by the segmented sorting functions when the
+// segment boundaries are malformed.
+//
+// Boundaries must be non-decreasing and lie within the data slice:
+//
+//	0 <= segments[k] <= segments[k+1] <= len(data)
+var ErrInvalidSegments = errors.New("segment boundaries are out of range or decreasing")
+
+// ErrInvalidRowLength is returned by the row sorting functions when the rows
+// do not share one positive length.
+//
+// For [][]T layouts every row must have the same length. For flat layouts the
+// stride must be positive and divide the data length:
+//
+//	len(data)%stride == 0
+var ErrInvalidRowLength = errors.New("rows have different lengths")
@@ -109,3 +109,31 @@ func ExampleUint64_error() {
 	// Output:
 	// buffer length is less than data length
 }
+
+// ExampleSortBy demonstrates sorting structs by field names without writing
+// a key function.
+func ExampleSortBy() {
+	type Order struct {
+		Customer string
+		Priority int
+		Total    float64
+	}
+
+	orders := []Order{
+		{"alice", 2, 10.5},
+		{"bob", 1, 99.0},
+		{"carol", 2, 42.0},
+	}
+
+	if err := radixsort.SortBy(orders, "Priority", "Total,desc"); err != nil {
+		panic(err)
+	}
+
+	for _, o := range orders {
+		fmt.Println(o.Customer, o.Priority, o.Total)
+	}
+	// Output:
+	// bob 1 99
+	// carol 2 42
+	// alice 2 10.5
+}
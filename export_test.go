@@ -0,0 +1,23 @@
+package radixsort
+
+// SetWideDigitMinLen sets the shortest input sorted with 11-bit digits, so
+// that tests can exercise those kernels on short inputs. It returns
+// a function that restores the previous value.
+func SetWideDigitMinLen(n int) (restore func()) {
+	prev := wideDigitMinLen.get()
+	wideDigitMinLen.set(n)
+	return func() { wideDigitMinLen.set(prev) }
+}
+
+// Histogram64 and PrefixSums64 are the histogram kernels used by the 64-bit
+// sort: assembly where available, the portable versions otherwise.
+var (
+	Histogram64  = histogram64
+	PrefixSums64 = prefixSums64
+)
+
+// Histogram64Generic and PrefixSums64Generic are the portable kernels.
+var (
+	Histogram64Generic  = histogram64Generic
+	PrefixSums64Generic = prefixSums64Generic
+)
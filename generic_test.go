@@ -1,6 +1,7 @@
 package radixsort_test
 
 import (
+	"errors"
 	"math"
 	"math/rand"
 	"slices"
@@ -782,3 +783,173 @@ func TestSortPointsByDistance(t *testing.T) {
 		})
 	}
 }
+
+func TestGenericCached(t *testing.T) {
+	type record struct {
+		Key     int32
+		Payload [60]byte
+	}
+
+	type celsius float32
+
+	tests := []struct {
+		name string
+		sort func(data, buf []record) error
+	}{
+		{
+			name: "GenericCached",
+			sort: func(data, buf []record) error {
+				return radixsort.GenericCached(data, buf, func(r record) int32 { return r.Key })
+			},
+		},
+		{
+			name: "Generic with large elements",
+			sort: func(data, buf []record) error {
+				return radixsort.Generic(data, buf, func(r record) int32 { return r.Key })
+			},
+		},
+		{
+			name: "GenericCached with named float key",
+			sort: func(data, buf []record) error {
+				return radixsort.GenericCached(data, buf, func(r record) celsius { return celsius(r.Key) / 4 })
+			},
+		},
+	}
+
+	input := make([]record, 100_000)
+	for i := range input {
+		input[i].Key = int32(rand.Intn(2000) - 1000)
+		input[i].Payload[0] = byte(i)
+		input[i].Payload[1] = byte(i >> 8)
+		input[i].Payload[2] = byte(i >> 16)
+	}
+
+	want := append([]record{}, input...)
+	slices.SortStableFunc(want, func(a, b record) int { return int(a.Key) - int(b.Key) })
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := append([]record{}, input...)
+			buf := make([]record, len(data))
+
+			err := tt.sort(data, buf)
+			if err != nil {
+				t.Fatalf("%s failed: %v", tt.name, err)
+			}
+
+			if !slices.Equal(want, data) {
+				t.Errorf("%s: result is not a stable sort of the input", tt.name)
+			}
+		})
+	}
+}
+
+func TestGenericCachedSelection(t *testing.T) {
+	type record struct {
+		Key     uint64
+		Payload [24]byte
+	}
+
+	words := func(keys []uint64) float64 {
+		return genericAllocs(t, keys, func(k uint64) uint64 { return k })
+	}
+	records := func(keys []uint64) float64 {
+		data := make([]record, len(keys))
+		for i, k := range keys {
+			data[i].Key = k
+		}
+		return genericAllocs(t, data, func(r record) uint64 { return r.Key })
+	}
+
+	// Generic sorts (key, index) pairs, which allocates, once it would move
+	// 64 bytes per element over all of its passes.
+	tests := []struct {
+		name      string
+		keyBits   uint
+		allocs    func(keys []uint64) float64
+		wantPairs bool
+	}{
+		{name: "four passes over 8-byte elements", keyBits: 32, allocs: words},
+		{name: "eight passes over 8-byte elements", keyBits: 64, allocs: words, wantPairs: true},
+		{name: "one pass over 32-byte elements", keyBits: 8, allocs: records},
+		{name: "two passes over 32-byte elements", keyBits: 16, allocs: records, wantPairs: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := make([]uint64, 10_000)
+			for i := range keys {
+				keys[i] = rand.Uint64() >> (64 - tt.keyBits)
+			}
+
+			if gotPairs := tt.allocs(keys) > 0; gotPairs != tt.wantPairs {
+				t.Errorf("Generic sorted pairs = %v, want %v", gotPairs, tt.wantPairs)
+			}
+		})
+	}
+}
+
+// genericAllocs sorts data with Generic and returns the number of allocations
+// the sort made.
+func genericAllocs[E any](t *testing.T, data []E, key func(E) uint64) float64 {
+	t.Helper()
+
+	input := slices.Clone(data)
+	buf := make([]E, len(data))
+	allocs := testing.AllocsPerRun(3, func() {
+		copy(data, input)
+		if err := radixsort.Generic(data, buf, key); err != nil {
+			t.Fatalf("Generic failed: %v", err)
+		}
+	})
+
+	for i := 1; i < len(data); i++ {
+		if key(data[i]) < key(data[i-1]) {
+			t.Errorf("Generic failed to sort data correctly")
+			break
+		}
+	}
+	return allocs
+}
+
+func TestGenericCachedSmallBuffer(t *testing.T) {
+	data := []uint64{3, 1, 2}
+	buf := make([]uint64, 2)
+
+	err := radixsort.GenericCached(data, buf, func(a uint64) uint64 { return a })
+	if !errors.Is(err, radixsort.ErrInvalidBufferSize) {
+		t.Errorf("GenericCached: error = %v, want %v", err, radixsort.ErrInvalidBufferSize)
+	}
+}
+
+func TestGenericNamedSignedKey(t *testing.T) {
+	type offset int16
+
+	data := []offset{3, -1, 0, -300, 2}
+	buf := make([]offset, len(data))
+
+	err := radixsort.Generic(data, buf, func(a offset) offset { return a })
+	if err != nil {
+		t.Fatalf("Generic failed: %v", err)
+	}
+
+	want := []offset{-300, -1, 0, 2, 3}
+	if !cmp.Equal(want, data) {
+		t.Errorf("Generic[offset] = %v, want %v", data, want)
+	}
+}
+
+func TestGenericSignedZero(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	data := []float64{1, negZero, -1, 0, -math.MaxFloat64}
+	buf := make([]float64, len(data))
+
+	err := radixsort.Generic(data, buf, func(a float64) float64 { return a })
+	if err != nil {
+		t.Fatalf("Generic failed: %v", err)
+	}
+
+	if !slices.IsSorted(data) || data[0] != -math.MaxFloat64 || !math.Signbit(data[2]) {
+		t.Errorf("Generic[float64] = %v, want -0 between negative and positive values", data)
+	}
+}
@@ -0,0 +1,42 @@
+package radixsort
+
+// The histogram and prefix-sum passes of the 64-bit kernel are implemented in
+// assembly on arm64 (hist_arm64.s), and the prefix sums also on amd64 with
+// AVX2 (hist_amd64.s). The functions below are the portable versions: they
+// are used everywhere else and with the purego build tag, and the tests
+// compare both paths on the same input.
+
+// histogram64Generic counts the eight bytes of v-base of every element of data
+// into offsets, which must be zero on entry. It returns the number of
+// descents of data, i.e. positions i where data[i] < data[i-1].
+func histogram64Generic(data []uint64, base uint64, offsets *[8][256]uint) int {
+	descents := 0
+	prev := uint64(0)
+	for _, v := range data {
+		if v < prev {
+			descents++
+		}
+		prev = v
+		v -= base
+		offsets[0][uint8(v>>(0*8))]++
+		offsets[1][uint8(v>>(1*8))]++
+		offsets[2][uint8(v>>(2*8))]++
+		offsets[3][uint8(v>>(3*8))]++
+		offsets[4][uint8(v>>(4*8))]++
+		offsets[5][uint8(v>>(5*8))]++
+		offsets[6][uint8(v>>(6*8))]++
+		offsets[7][uint8(v>>(7*8))]++
+	}
+	return descents
+}
+
+// prefixSums64Generic converts the counters of every digit in offsets into
+// exclusive prefix sums, the start offsets of the buckets.
+func prefixSums64Generic(offsets *[8][256]uint) {
+	for d := range offsets {
+		acc := uint(0)
+		for b, c := range offsets[d] {
+			offsets[d][b], acc = acc, acc+c
+		}
+	}
+}
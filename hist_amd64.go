@@ -0,0 +1,56 @@
+//go:build !purego
+
+package radixsort
+
+// hasAVX2 reports whether the CPU and the operating system support AVX2.
+var hasAVX2 = detectAVX2()
+
+// detectAVX2 checks the AVX2 feature bit and that the operating system saves
+// the YMM registers on context switches.
+func detectAVX2() bool {
+	maxLeaf, _, _, _ := cpuid(0, 0)
+	if maxLeaf < 7 {
+		return false
+	}
+	_, _, ecx1, _ := cpuid(1, 0)
+	const osxsave, avx = 1 << 27, 1 << 28
+	if ecx1&(osxsave|avx) != osxsave|avx {
+		return false
+	}
+	if xcr0, _ := xgetbv(); xcr0&0b110 != 0b110 {
+		return false
+	}
+	_, ebx7, _, _ := cpuid(7, 0)
+	return ebx7&(1<<5) != 0
+}
+
+//go:noescape
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+//go:noescape
+func xgetbv() (eax, edx uint32)
+
+// prefixSums64AVX2 is prefixSums64Generic with an in-register scan of four
+// counters at a time.
+//
+//go:noescape
+func prefixSums64AVX2(offsets *[8][256]uint)
+
+// histogram64 counts the bytes of v-base of data into offsets and returns the
+// number of descents, see [histogram64Generic].
+//
+// The portable loop is used: an assembly version, bound like it by the eight
+// counter increments per element, was no faster (see BenchmarkHistogram).
+func histogram64(data []uint64, base uint64, offsets *[8][256]uint) int {
+	return histogram64Generic(data, base, offsets)
+}
+
+// prefixSums64 converts the counters in offsets into start offsets, see
+// [prefixSums64Generic].
+func prefixSums64(offsets *[8][256]uint) {
+	if hasAVX2 {
+		prefixSums64AVX2(offsets)
+		return
+	}
+	prefixSums64Generic(offsets)
+}
@@ -0,0 +1,58 @@
+//go:build !purego
+
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	MOVL DX, edx+4(FP)
+	RET
+
+// func prefixSums64AVX2(offsets *[8][256]uint)
+//
+// Every block of four counters c is scanned in two shift-and-add steps to its
+// inclusive prefix sums; subtracting c and adding the running total of the
+// table gives the start offsets. Y0 holds the running total in all lanes.
+TEXT ·prefixSums64AVX2(SB), NOSPLIT, $0-8
+	MOVQ  offsets+0(FP), DI
+	MOVQ  $8, R10
+	VPXOR Y15, Y15, Y15
+
+table:
+	VPXOR Y0, Y0, Y0
+	MOVQ  $64, CX
+
+block:
+	VMOVDQU  (DI), Y1
+	VPERMQ   $0x90, Y1, Y2       // c0 c0 c1 c2
+	VPBLENDD $0x03, Y15, Y2, Y2  // 0 c0 c1 c2
+	VPADDQ   Y1, Y2, Y2          // c0 c0+c1 c1+c2 c2+c3
+	VPERMQ   $0x40, Y2, Y3       // s0 s0 s0 s1
+	VPBLENDD $0x0f, Y15, Y3, Y3  // 0 0 s0 s1
+	VPADDQ   Y2, Y3, Y3          // inclusive sums
+	VPSUBQ   Y1, Y3, Y4          // exclusive sums
+	VPADDQ   Y0, Y4, Y4
+	VMOVDQU  Y4, (DI)
+	VPERMQ   $0xff, Y3, Y5       // block total in all lanes
+	VPADDQ   Y5, Y0, Y0
+	ADDQ     $32, DI
+	DECQ     CX
+	JNZ      block
+
+	DECQ R10
+	JNZ  table
+	VZEROUPPER
+	RET
@@ -0,0 +1,26 @@
+//go:build !purego
+
+package radixsort
+
+// histogram64Asm is histogram64Generic in assembly. Every byte is extracted
+// with one bit-field instruction and counted without bounds checks.
+//
+//go:noescape
+func histogram64Asm(data []uint64, base uint64, offsets *[8][256]uint) int
+
+// prefixSums64Asm is prefixSums64Generic in assembly.
+//
+//go:noescape
+func prefixSums64Asm(offsets *[8][256]uint)
+
+// histogram64 counts the bytes of v-base of data into offsets and returns the
+// number of descents, see [histogram64Generic].
+func histogram64(data []uint64, base uint64, offsets *[8][256]uint) int {
+	return histogram64Asm(data, base, offsets)
+}
+
+// prefixSums64 converts the counters in offsets into start offsets, see
+// [prefixSums64Generic].
+func prefixSums64(offsets *[8][256]uint) {
+	prefixSums64Asm(offsets)
+}
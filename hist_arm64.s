@@ -0,0 +1,62 @@
+//go:build !purego
+
+#include "textflag.h"
+
+// COUNT increments the counter of byte d of R6 in table d.
+#define COUNT(d) \
+	UBFX $(d*8), R6, $8, R7 \
+	ADD  R7<<3, R3, R8 \
+	MOVD (d*2048)(R8), R9 \
+	ADD  $1, R9 \
+	MOVD R9, (d*2048)(R8)
+
+// func histogram64Asm(data []uint64, base uint64, offsets *[8][256]uint) int
+TEXT ·histogram64Asm(SB), NOSPLIT, $0-48
+	MOVD data_base+0(FP), R0
+	MOVD data_len+8(FP), R1
+	MOVD base+24(FP), R2
+	MOVD offsets+32(FP), R3
+	MOVD $0, R4 // previous element
+	MOVD $0, R5 // descents
+	CBZ  R1, done
+
+loop:
+	MOVD.P 8(R0), R6
+	CMP    R4, R6
+	CINC   LO, R5, R5 // v < prev
+	MOVD   R6, R4
+	SUB    R2, R6, R6
+	COUNT(0)
+	COUNT(1)
+	COUNT(2)
+	COUNT(3)
+	COUNT(4)
+	COUNT(5)
+	COUNT(6)
+	COUNT(7)
+	SUB    $1, R1
+	CBNZ   R1, loop
+
+done:
+	MOVD R5, ret+40(FP)
+	RET
+
+// func prefixSums64Asm(offsets *[8][256]uint)
+TEXT ·prefixSums64Asm(SB), NOSPLIT, $0-8
+	MOVD offsets+0(FP), R0
+	MOVD $8, R1
+
+table:
+	MOVD $0, R3 // running total
+	MOVD $256, R2
+
+counter:
+	MOVD   (R0), R4
+	MOVD.P R3, 8(R0)
+	ADD    R4, R3, R3
+	SUB    $1, R2
+	CBNZ   R2, counter
+
+	SUB  $1, R1
+	CBNZ R1, table
+	RET
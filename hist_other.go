@@ -0,0 +1,15 @@
+//go:build (!amd64 && !arm64) || purego
+
+package radixsort
+
+// histogram64 counts the bytes of v-base of data into offsets and returns the
+// number of descents, see [histogram64Generic].
+func histogram64(data []uint64, base uint64, offsets *[8][256]uint) int {
+	return histogram64Generic(data, base, offsets)
+}
+
+// prefixSums64 converts the counters in offsets into start offsets, see
+// [prefixSums64Generic].
+func prefixSums64(offsets *[8][256]uint) {
+	prefixSums64Generic(offsets)
+}
@@ -0,0 +1,163 @@
+package radixsort_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+func TestHistogramKernels(t *testing.T) {
+	// Odd lengths and the empty slice exercise the loop bounds of the
+	// assembly; the base is the minimum, zero or an arbitrary value, which
+	// makes v-base wrap around.
+	tests := []struct {
+		name string
+		data []uint64
+		base uint64
+	}{
+		{"empty", nil, 0},
+		{"one", []uint64{0x0102_0304_0506_0708}, 0},
+		{"max", []uint64{^uint64(0), ^uint64(0), 0}, 0},
+		{"sorted", sortedUint64(1000), 0},
+		{"descending", descendingUint64(1001), 0},
+		{"random", randomUint64(4099), 0},
+		{"random_base", randomUint64(4099), 1 << 40},
+		{"narrow", narrowUint64(777), 1 << 33},
+		{"skewed", skewedUint64(5000), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got, want [8][256]uint
+			gotDescents := radixsort.Histogram64(tt.data, tt.base, &got)
+			wantDescents := radixsort.Histogram64Generic(tt.data, tt.base, &want)
+
+			if gotDescents != wantDescents {
+				t.Errorf("descents = %d, want %d", gotDescents, wantDescents)
+			}
+			if got != want {
+				t.Fatal("counters differ from the portable kernel")
+			}
+
+			radixsort.PrefixSums64(&got)
+			radixsort.PrefixSums64Generic(&want)
+			if got != want {
+				t.Fatal("offsets differ from the portable kernel")
+			}
+			for d := range got {
+				if got[d][0] != 0 {
+					t.Errorf("offsets[%d][0] = %d, want 0", d, got[d][0])
+				}
+			}
+		})
+	}
+}
+
+func TestPrefixSumsLargeCounters(t *testing.T) {
+	// Counters that do not fit into 32 bits must not be truncated by the
+	// vector lanes.
+	var got, want [8][256]uint
+	for d := range got {
+		for b := range got[d] {
+			got[d][b] = uint(rand.Uint32()) << 20
+		}
+	}
+	want = got
+
+	radixsort.PrefixSums64(&got)
+	radixsort.PrefixSums64Generic(&want)
+	if got != want {
+		t.Fatal("offsets differ from the portable kernel")
+	}
+}
+
+func sortedUint64(n int) []uint64 {
+	data := randomUint64(n)
+	slices.Sort(data)
+	return data
+}
+
+func descendingUint64(n int) []uint64 {
+	data := sortedUint64(n)
+	slices.Reverse(data)
+	return data
+}
+
+func randomUint64(n int) []uint64 {
+	data := make([]uint64, n)
+	for i := range data {
+		data[i] = rand.Uint64()
+	}
+	return data
+}
+
+func narrowUint64(n int) []uint64 {
+	data := make([]uint64, n)
+	for i := range data {
+		data[i] = 1<<33 + uint64(rand.Intn(1<<20))
+	}
+	return data
+}
+
+func skewedUint64(n int) []uint64 {
+	data := make([]uint64, n)
+	for i := range data {
+		data[i] = uint64(rand.Intn(3)) * 0x0101_0101_0101_0101
+	}
+	return data
+}
+
+// BenchmarkHistogram compares the histogram and prefix-sum kernels of the
+// 64-bit sort with their portable versions.
+func BenchmarkHistogram(b *testing.B) {
+	histograms := []struct {
+		name      string
+		histogram func([]uint64, uint64, *[8][256]uint) int
+	}{
+		{name: "Kernel", histogram: radixsort.Histogram64},
+		{name: "Generic", histogram: radixsort.Histogram64Generic},
+	}
+
+	for _, size := range []int{1 << 10, 1 << 16, 1 << 20} {
+		data := randomUint64(size)
+		for _, h := range histograms {
+			b.Run(fmt.Sprintf("%s_%d", h.name, size), func(b *testing.B) {
+				b.SetBytes(int64(size) * 8)
+				for b.Loop() {
+					var offsets [8][256]uint
+					h.histogram(data, 0, &offsets)
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkPrefixSums compares the prefix-sum kernel of the 64-bit sort with
+// its portable version.
+func BenchmarkPrefixSums(b *testing.B) {
+	prefixSums := []struct {
+		name       string
+		prefixSums func(*[8][256]uint)
+	}{
+		{name: "Kernel", prefixSums: radixsort.PrefixSums64},
+		{name: "Generic", prefixSums: radixsort.PrefixSums64Generic},
+	}
+
+	var counts [8][256]uint
+	for d := range counts {
+		for b := range counts[d] {
+			counts[d][b] = uint(rand.Intn(1000))
+		}
+	}
+	for _, p := range prefixSums {
+		b.Run(p.name, func(b *testing.B) {
+			for b.Loop() {
+				offsets := counts
+				p.prefixSums(&offsets)
+			}
+		})
+	}
+}
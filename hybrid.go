@@ -0,0 +1,193 @@
+package radixsort
+
+import (
+	"math/bits"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// hybridMinLen is the shortest input of 64-bit keys that is partitioned by
+// its most significant byte before the LSD passes (see hybrid64).
+//
+// Every LSD pass streams the whole array through memory. Once the data and
+// the buffer no longer fit into the last-level cache, one MSD pass that
+// splits them into cache-sized buckets leaves the remaining passes running
+// within the cache. Below it the extra pass costs more than the locality
+// gains (see BenchmarkLargeUint64).
+var hybridMinLen = newThreshold(1 << 19)
+
+// hybridBucketMax is the longest bucket that is sorted with LSD passes after
+// the partition; longer buckets are partitioned by the next byte. A bucket
+// and its part of the buffer take at most 2 MiB, the size of a typical L2.
+// Smaller buckets measured slower on long inputs, since partitioning by a
+// second byte leaves buckets too short to amortize their 256 counters.
+const hybridBucketMax = 1 << 17
+
+// hybrid64 sorts data by the 8-bit digits of v-base, where base must not be
+// greater than any element and the bytes above shift must be identical across
+// all elements. The subtraction wraps around, so base 1<<63 sorts the two's
+// complement representation of signed integers. The elements are partitioned
+// stably by the byte at shift into buf, and every bucket is then sorted on its
+// own: with the LSD kernel if it fits into the cache, or by partitioning it
+// again by the next byte.
+//
+// The buckets are independent, so with workers > 1 the partition is computed
+// with per-worker histograms and the buckets are distributed over workers
+// goroutines, see [hybridParallel]. The sort is stable either way, and with
+// a single worker it allocates nothing.
+// The buffer length must be at least as large as data.
+func hybrid64(data, buf []uint64, base uint64, shift uint, workers int) {
+	// counts is the histogram of the byte at shift. With workers > 1, parts[w]
+	// is the histogram of the chunk of worker w and becomes its offsets.
+	var counts [256]uint
+	var parts [][256]uint
+	descents := 0
+	if workers < 2 {
+		descents = hybridCount(data, 0, base, shift, &counts)
+	} else {
+		parts = make([][256]uint, workers)
+		descents = hybridCountParallel(data, base, shift, parts)
+		for w := range parts {
+			for b, c := range parts[w] {
+				counts[b] += c
+			}
+		}
+	}
+
+	// Sorted and strictly descending input is finished here. Merging a few
+	// runs is left to the buckets, since it compares the elements rather
+	// than their keys v-base.
+	switch descents {
+	case 0:
+		return
+	case len(data) - 1:
+		slices.Reverse(data)
+		return
+	}
+
+	// If all elements share the byte at shift, the partition would only
+	// copy them: partition by the next byte instead.
+	if counts[uint8((data[0]-base)>>shift)] == uint(len(data)) && shift > 0 {
+		hybrid64(data, buf, base, shift-8, workers)
+		return
+	}
+
+	// bounds[b] is the start of bucket b in buf; bounds[256] is its end.
+	var bounds [257]int
+	acc := uint(0)
+	for b := range 256 {
+		bounds[b] = int(acc)
+		if parts == nil {
+			counts[b], acc = acc, acc+counts[b]
+			continue
+		}
+		for w := range parts {
+			parts[w][b], acc = acc, acc+parts[w][b]
+		}
+	}
+	bounds[256] = len(data)
+
+	if parts != nil {
+		hybridParallel(data, buf, base, shift, parts, bounds)
+		return
+	}
+
+	hybridScatter(data, buf, base, shift, &counts)
+	for b := range 256 {
+		hybridBucket(buf[bounds[b]:bounds[b+1]], data[bounds[b]:bounds[b+1]], base, shift)
+	}
+}
+
+// hybridCount adds the histogram of the byte at shift of v-base to c and
+// returns the number of descents in data, where prev is the key v-base of
+// the element before data.
+func hybridCount(data []uint64, prev, base uint64, shift uint, c *[256]uint) int {
+	descents := 0
+	for _, v := range data {
+		v -= base
+		if v < prev {
+			descents++
+		}
+		prev = v
+		c[uint8(v>>shift)]++
+	}
+	return descents
+}
+
+// hybridCountParallel computes the histogram of every worker's chunk into
+// parts, see [hybridCount], and returns the number of descents in data,
+// including those between chunks.
+func hybridCountParallel(data []uint64, base uint64, shift uint, parts [][256]uint) int {
+	descents := make([]int, len(parts))
+	parallelChunks(len(data), len(parts), func(w, lo, hi int) {
+		prev := uint64(0)
+		if lo > 0 {
+			prev = data[lo-1] - base
+		}
+		descents[w] = hybridCount(data[lo:hi], prev, base, shift, &parts[w])
+	})
+
+	total := 0
+	for _, d := range descents {
+		total += d
+	}
+	return total
+}
+
+// hybridScatter moves the elements of data into buf at the offsets o of the
+// byte at shift of v-base.
+func hybridScatter(data, buf []uint64, base uint64, shift uint, o *[256]uint) {
+	for _, v := range data {
+		b := uint8((v - base) >> shift)
+		buf[o[b]] = v
+		o[b]++
+	}
+}
+
+// hybridBucket sorts a bucket that hybrid64 partitioned by the byte at shift
+// into its buffer, using the same part of the data as scratch, and leaves the
+// result in scratch.
+func hybridBucket(bucket, scratch []uint64, base uint64, shift uint) {
+	if len(bucket) < 2 {
+		copy(scratch, bucket)
+		return
+	}
+
+	if len(bucket) <= hybridBucketMax || shift == 0 {
+		_ = radix64b8Base(bucket, scratch, base)
+	} else {
+		hybrid64(bucket, scratch, base, shift-8, 1)
+	}
+	copy(scratch, bucket)
+}
+
+// hybridParallel partitions data into buf with the per-worker offsets parts
+// and sorts the buckets between bounds on len(parts) goroutines.
+func hybridParallel(data, buf []uint64, base uint64, shift uint, parts [][256]uint, bounds [257]int) {
+	parallelChunks(len(data), len(parts), func(w, lo, hi int) {
+		hybridScatter(data[lo:hi], buf, base, shift, &parts[w])
+	})
+
+	// Buckets differ in size, so the workers take the next bucket as soon as
+	// they are done with one.
+	var next atomic.Int32
+	var wg sync.WaitGroup
+	wg.Add(len(parts))
+	for range len(parts) {
+		go func() {
+			defer wg.Done()
+			for b := int(next.Add(1) - 1); b < 256; b = int(next.Add(1) - 1) {
+				lo, hi := bounds[b], bounds[b+1]
+				hybridBucket(buf[lo:hi], data[lo:hi], base, shift)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// topShift returns the shift of the most significant byte that is set in
+// keyRange, or 0 if there is none.
+func topShift(keyRange uint64) uint {
+	return uint(max(bits.Len64(keyRange)-1, 0)) / 8 * 8
+}
@@ -0,0 +1,94 @@
+package radixsort_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+func TestHybrid(t *testing.T) {
+	// Long enough to be partitioned by the most significant byte first.
+	const size = 1<<19 + 13
+
+	inputs := map[string]func(i int) uint64{
+		"uniform": func(int) uint64 { return rand.Uint64() },
+		// Most elements fall into one bucket, which is partitioned again.
+		"skewed": func(int) uint64 {
+			if rand.Intn(8) == 0 {
+				return rand.Uint64()
+			}
+			return 0x0100_0000_0000_0000 + uint64(rand.Intn(1<<30))
+		},
+		// Several leading bytes are shared by all elements.
+		"narrow": func(int) uint64 { return 1<<40 + uint64(rand.Intn(1<<24)) },
+		// A narrow range crossing a byte boundary, sorted relative to the minimum.
+		"timestamps": func(int) uint64 { return 1_700_000_000_000 + uint64(rand.Intn(1<<28)) },
+		"sorted":     func(i int) uint64 { return uint64(i) << 20 },
+		"descending": func(i int) uint64 { return uint64(size-i) << 20 },
+		"two_runs":   func(i int) uint64 { return uint64(i%(size/2)) << 30 },
+		"equal":      func(int) uint64 { return 42 },
+	}
+
+	for name, gen := range inputs {
+		t.Run(name, func(t *testing.T) {
+			input := make([]uint64, size)
+			for i := range input {
+				input[i] = gen(i)
+			}
+			want := slices.Clone(input)
+			slices.Sort(want)
+
+			data := slices.Clone(input)
+			if err := radixsort.Uint64(data, make([]uint64, size)); err != nil {
+				t.Fatalf("Uint64 failed: %v", err)
+			}
+			if !slices.Equal(want, data) {
+				t.Error("Uint64 failed to sort data correctly")
+			}
+
+			data = slices.Clone(input)
+			if err := eager.Uint64(data, make([]uint64, size)); err != nil {
+				t.Fatalf("Parallel.Uint64 failed: %v", err)
+			}
+			if !slices.Equal(want, data) {
+				t.Error("Parallel.Uint64 failed to sort data correctly")
+			}
+
+			signed := make([]int64, size)
+			for i, v := range input {
+				signed[i] = int64(v) - 1<<62
+			}
+			wantSigned := slices.Clone(signed)
+			slices.Sort(wantSigned)
+			if err := eager.Int64(signed, make([]uint64, size)); err != nil {
+				t.Fatalf("Parallel.Int64 failed: %v", err)
+			}
+			if !slices.Equal(wantSigned, signed) {
+				t.Error("Parallel.Int64 failed to sort data correctly")
+			}
+		})
+	}
+}
+
+func TestHybridAllocs(t *testing.T) {
+	const size = 1 << 20
+	input := make([]uint64, size)
+	for i := range input {
+		input[i] = rand.Uint64()
+	}
+	data := make([]uint64, size)
+	buf := make([]uint64, size)
+
+	allocs := testing.AllocsPerRun(3, func() {
+		copy(data, input)
+		_ = radixsort.Uint64(data, buf)
+	})
+	if allocs != 0 {
+		t.Errorf("Uint64 made %v allocations, want 0", allocs)
+	}
+	if !slices.IsSorted(data) {
+		t.Errorf("Uint64 failed to sort data correctly")
+	}
+}
@@ -0,0 +1,249 @@
+package radixsort
+
+import (
+	"unsafe"
+
+	"github.com/sagernet/sing/common/x/constraints"
+)
+
+// msdInsertionMax is the longest MSD bucket that is finished with insertion
+// sort instead of another partitioning pass.
+const msdInsertionMax = 32
+
+// InPlaceUint64 sorts a slice of uint64 values in ascending order without
+// a temporary buffer.
+//
+// It implements American flag sort: an MSD radix sort that partitions the
+// data by the most significant byte in place, moving every element directly
+// into its bucket by following permutation cycles, and then recurses into
+// each bucket with the next byte. Leading bytes that are identical across
+// a bucket are skipped, and short buckets are finished with insertion sort.
+//
+// The sort is not stable, which makes no difference for plain integers but
+// matters for [InPlaceGeneric]. It needs only O(1) extra memory besides a few
+// kilobytes of stack, so it can sort datasets that fit in memory only once.
+//
+// Example:
+//
+//	data := []uint64{5, 2, 9, 1, 5, 6}
+//	InPlaceUint64(data)
+//	// data is now sorted: [1, 2, 5, 5, 6, 9]
+func InPlaceUint64(data []uint64) {
+	americanFlag(data, nil, 7*8, nil)
+}
+
+// InPlaceUint32 sorts a slice of uint32 values in ascending order without
+// a temporary buffer.
+//
+// See [InPlaceUint64] for details.
+func InPlaceUint32(data []uint32) {
+	americanFlag(data, nil, 3*8, nil)
+}
+
+// InPlaceUint16 sorts a slice of uint16 values in ascending order without
+// a temporary buffer.
+//
+// Long inputs of values spanning at most 256 consecutive keys are sorted by
+// counting as in [Uint16]; wider ranges would need memory for the counters.
+// See [InPlaceUint64] for the other cases.
+func InPlaceUint16(data []uint16) {
+	if countingSortRange(data, nil) {
+		return
+	}
+	americanFlag(data, nil, 1*8, nil)
+}
+
+// InPlaceUint8 sorts a slice of uint8 values in ascending order without
+// a temporary buffer, by counting the values and writing each of them back
+// as often as it occurs.
+func InPlaceUint8(data []uint8) {
+	countingSort8(data)
+}
+
+// InPlaceInt64 sorts a slice of int64 values in ascending order without
+// a temporary buffer.
+//
+// See [InPlaceUint64] for details.
+func InPlaceInt64(data []int64) {
+	inPlaceSigned(*(*[]uint64)(unsafe.Pointer(&data)), 1<<63, 7*8)
+}
+
+// InPlaceInt32 sorts a slice of int32 values in ascending order without
+// a temporary buffer.
+//
+// See [InPlaceUint64] for details.
+func InPlaceInt32(data []int32) {
+	inPlaceSigned(*(*[]uint32)(unsafe.Pointer(&data)), 1<<31, 3*8)
+}
+
+// InPlaceInt16 sorts a slice of int16 values in ascending order without
+// a temporary buffer.
+//
+// See [InPlaceUint16] for details.
+func InPlaceInt16(data []int16) {
+	if countingSortRange(data, nil) {
+		return
+	}
+	inPlaceSigned(*(*[]uint16)(unsafe.Pointer(&data)), 1<<15, 1*8)
+}
+
+// InPlaceInt8 sorts a slice of int8 values in ascending order without
+// a temporary buffer.
+//
+// See [InPlaceUint8] for details.
+func InPlaceInt8(data []int8) {
+	countingSort8(data)
+}
+
+// InPlaceGeneric sorts a slice of elements by a numeric key without
+// a temporary buffer.
+//
+// Keys are ordered as in [Generic]. The key function is called about twice
+// per element for every byte of the key that has to be examined.
+//
+// Unlike Generic, the sort is NOT stable: elements with equal keys may be
+// reordered. See [InPlaceUint64] for a description of the algorithm.
+//
+// Example:
+//
+//	type Item struct{ Score float64 }
+//	items := []Item{{95.5}, {87.3}, {92.1}}
+//	InPlaceGeneric(items, func(i Item) float64 { return i.Score })
+func InPlaceGeneric[E any, N ConstraintNumbers](data []E, key func(a E) N) {
+	var keyZeroValue N
+	americanFlagFunc(data, orderedKeyFunc(key), uint(unsafe.Sizeof(keyZeroValue)-1)*8)
+}
+
+// inPlaceSigned sorts signed integers in their unsigned representation.
+// The sign bit is flipped before and after sorting so that negative values
+// order first.
+func inPlaceSigned[T constraints.Unsigned](data []T, signBit T, shift uint) {
+	flipBits(data, signBit)
+	americanFlag(data, nil, shift, nil)
+	flipBits(data, signBit)
+}
+
+// americanFlag sorts data in place by the bytes at shift and below.
+//
+// If kernel is not nil, buckets that fit into buf are sorted by kernel
+// instead of being partitioned further.
+func americanFlag[T constraints.Unsigned](data, buf []T, shift uint, kernel func(data, buf []T) error) {
+	for {
+		if len(data) <= msdInsertionMax {
+			insertionSort(data)
+			return
+		}
+		if kernel != nil && len(data) <= len(buf) {
+			_ = sortShort(data, buf, kernel)
+			return
+		}
+
+		var counts [256]int
+		for _, v := range data {
+			counts[uint8(v>>shift)]++
+		}
+
+		// All elements share this byte: move on to the next one without
+		// permuting anything.
+		if counts[uint8(data[0]>>shift)] == len(data) {
+			if shift == 0 {
+				return
+			}
+			shift -= 8
+			continue
+		}
+
+		var heads, tails [256]int
+		acc := 0
+		for b, c := range counts {
+			heads[b] = acc
+			acc += c
+			tails[b] = acc
+		}
+
+		// Cycle leader permutation: take the first misplaced element of each
+		// bucket and keep swapping it into the bucket it belongs to.
+		for b := range 256 {
+			for heads[b] < tails[b] {
+				v := data[heads[b]]
+				d := uint8(v >> shift)
+				for int(d) != b {
+					v, data[heads[d]] = data[heads[d]], v
+					heads[d]++
+					d = uint8(v >> shift)
+				}
+				data[heads[b]] = v
+				heads[b]++
+			}
+		}
+
+		if shift == 0 {
+			return
+		}
+		start := 0
+		for _, end := range tails {
+			if end-start > 1 {
+				americanFlag(data[start:end], buf, shift-8, kernel)
+			}
+			start = end
+		}
+		return
+	}
+}
+
+// americanFlagFunc sorts data in place by the bytes of key at shift and below.
+func americanFlagFunc[E any](data []E, key func(a E) uint64, shift uint) {
+	for {
+		if len(data) <= msdInsertionMax {
+			insertionSortFunc(data, key)
+			return
+		}
+
+		var counts [256]int
+		for _, e := range data {
+			counts[uint8(key(e)>>shift)]++
+		}
+
+		if counts[uint8(key(data[0])>>shift)] == len(data) {
+			if shift == 0 {
+				return
+			}
+			shift -= 8
+			continue
+		}
+
+		var heads, tails [256]int
+		acc := 0
+		for b, c := range counts {
+			heads[b] = acc
+			acc += c
+			tails[b] = acc
+		}
+
+		for b := range 256 {
+			for heads[b] < tails[b] {
+				e := data[heads[b]]
+				d := uint8(key(e) >> shift)
+				for int(d) != b {
+					e, data[heads[d]] = data[heads[d]], e
+					heads[d]++
+					d = uint8(key(e) >> shift)
+				}
+				data[heads[b]] = e
+				heads[b]++
+			}
+		}
+
+		if shift == 0 {
+			return
+		}
+		start := 0
+		for _, end := range tails {
+			if end-start > 1 {
+				americanFlagFunc(data[start:end], key, shift-8)
+			}
+			start = end
+		}
+		return
+	}
+}
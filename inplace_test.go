@@ -0,0 +1,120 @@
+package radixsort_test
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+// noBuffer adapts an in-place sorting function to the signature of the
+// buffered ones, so that the shared test helpers can be reused.
+func noBuffer[T any, B any](sortFunc func([]T)) func([]T, []B) error {
+	return func(data []T, _ []B) error {
+		sortFunc(data)
+		return nil
+	}
+}
+
+func TestInPlaceUint8(t *testing.T) {
+	testUnsignedSort(t, noBuffer[uint8, uint8](radixsort.InPlaceUint8), "InPlaceUint8")
+	testUnsignedSortLargeRandom(t, noBuffer[uint8, uint8](radixsort.InPlaceUint8), "InPlaceUint8")
+}
+
+func TestInPlaceUint16(t *testing.T) {
+	testUnsignedSort(t, noBuffer[uint16, uint16](radixsort.InPlaceUint16), "InPlaceUint16")
+	testUnsignedSortLargeRandom(t, noBuffer[uint16, uint16](radixsort.InPlaceUint16), "InPlaceUint16")
+}
+
+func TestInPlaceUint32(t *testing.T) {
+	testUnsignedSort(t, noBuffer[uint32, uint32](radixsort.InPlaceUint32), "InPlaceUint32")
+	testUnsignedSortLargeRandom(t, noBuffer[uint32, uint32](radixsort.InPlaceUint32), "InPlaceUint32")
+}
+
+func TestInPlaceUint64(t *testing.T) {
+	testUnsignedSort(t, noBuffer[uint64, uint64](radixsort.InPlaceUint64), "InPlaceUint64")
+	testUnsignedSortLargeRandom(t, noBuffer[uint64, uint64](radixsort.InPlaceUint64), "InPlaceUint64")
+}
+
+func TestInPlaceInt8(t *testing.T) {
+	testSignedSort(t, noBuffer[int8, uint8](radixsort.InPlaceInt8), "InPlaceInt8")
+	testSignedSortLargeRandom(t, noBuffer[int8, uint8](radixsort.InPlaceInt8), "InPlaceInt8")
+}
+
+func TestInPlaceInt16(t *testing.T) {
+	testSignedSort(t, noBuffer[int16, uint16](radixsort.InPlaceInt16), "InPlaceInt16")
+	testSignedSortLargeRandom(t, noBuffer[int16, uint16](radixsort.InPlaceInt16), "InPlaceInt16")
+}
+
+func TestInPlaceInt32(t *testing.T) {
+	testSignedSort(t, noBuffer[int32, uint32](radixsort.InPlaceInt32), "InPlaceInt32")
+	testSignedSortLargeRandom(t, noBuffer[int32, uint32](radixsort.InPlaceInt32), "InPlaceInt32")
+}
+
+func TestInPlaceInt64(t *testing.T) {
+	testSignedSort(t, noBuffer[int64, uint64](radixsort.InPlaceInt64), "InPlaceInt64")
+	testSignedSortLargeRandom(t, noBuffer[int64, uint64](radixsort.InPlaceInt64), "InPlaceInt64")
+}
+
+func TestInPlaceUint64Distributions(t *testing.T) {
+	const size = 200_000
+
+	inputs := map[string]func(i int) uint64{
+		"constant":         func(int) uint64 { return 42 },
+		"shared high bits": func(int) uint64 { return 0xdead_beef_0000_0000 | uint64(rand.Uint32()) },
+		"few values":       func(int) uint64 { return uint64(rand.Intn(3)) << 40 },
+		"sorted":           func(i int) uint64 { return uint64(i) },
+		"reverse":          func(i int) uint64 { return uint64(size - i) },
+	}
+
+	for name, gen := range inputs {
+		t.Run(name, func(t *testing.T) {
+			data := make([]uint64, size)
+			for i := range data {
+				data[i] = gen(i)
+			}
+			want := slices.Clone(data)
+			slices.Sort(want)
+
+			radixsort.InPlaceUint64(data)
+
+			if !slices.Equal(want, data) {
+				t.Errorf("InPlaceUint64 failed to sort %s input", name)
+			}
+		})
+	}
+}
+
+func TestInPlaceGeneric(t *testing.T) {
+	type item struct {
+		Score float64
+		ID    int
+	}
+
+	const size = 100_000
+	data := make([]item, size)
+	for i := range data {
+		data[i] = item{Score: math.Round(rand.NormFloat64()*1000) / 8, ID: i}
+	}
+	data[0].Score = math.Inf(-1)
+	data[1].Score = math.MaxFloat64
+
+	want := slices.Clone(data)
+	slices.SortStableFunc(want, func(a, b item) int { return cmp.Compare(a.Score, b.Score) })
+
+	radixsort.InPlaceGeneric(data, func(a item) float64 { return a.Score })
+
+	if !slices.IsSortedFunc(data, func(a, b item) int { return cmp.Compare(a.Score, b.Score) }) {
+		t.Fatalf("InPlaceGeneric failed to sort data correctly")
+	}
+
+	// The sort is unstable, so compare the multisets of elements.
+	slices.SortFunc(data, func(a, b item) int { return a.ID - b.ID })
+	slices.SortFunc(want, func(a, b item) int { return a.ID - b.ID })
+	if !slices.Equal(want, data) {
+		t.Errorf("InPlaceGeneric did not preserve the elements")
+	}
+}
@@ -0,0 +1,66 @@
+package radixsort
+
+import (
+	"unsafe"
+
+	"github.com/sagernet/sing/common/x/constraints"
+)
+
+// smallSortMax holds, indexed by the key width in bytes, the longest input
+// that the entry points sort with insertion sort instead of radix sort.
+//
+// A radix pass costs a fixed setup of 256 counters per key byte, which
+// insertion sort beats on short inputs. The values are just below the
+// crossover points measured with BenchmarkSmall for random keys: wider keys
+// have more passes to amortize and so cross over later. A tuning profile may
+// replace them with the crossover points of the local machine (see Profile).
+var smallSortMax = [9]*threshold{1: newThreshold(32), 2: newThreshold(64), 4: newThreshold(128), 8: newThreshold(128)}
+
+// isSmall reports whether n keys of size bytes are sorted faster with
+// insertion sort.
+func isSmall(n int, size uintptr) bool {
+	return n <= smallSortMax[size].get()
+}
+
+// sortShort sorts data with insertion sort if it is short and with kernel
+// otherwise. The buffer length must be at least as large as data.
+func sortShort[T constraints.Unsigned](data, buf []T, kernel func(data, buf []T) error) error {
+	if isSmall(len(data), unsafe.Sizeof(T(0))) {
+		if len(buf) < len(data) {
+			return ErrInvalidBufferSize
+		}
+		insertionSort(data)
+		return nil
+	}
+	return kernel(data, buf)
+}
+
+// insertionSort sorts data in ascending order using insertion sort.
+//
+// It is used for inputs that are too short to amortize the setup of the
+// offset tables of a radix pass. Insertion sort is stable.
+func insertionSort[T constraints.Ordered](data []T) {
+	for i := 1; i < len(data); i++ {
+		v := data[i]
+		j := i
+		for j > 0 && data[j-1] > v {
+			data[j] = data[j-1]
+			j--
+		}
+		data[j] = v
+	}
+}
+
+// insertionSortFunc sorts data in ascending order of key using insertion sort.
+func insertionSortFunc[E any](data []E, key func(a E) uint64) {
+	for i := 1; i < len(data); i++ {
+		e := data[i]
+		k := key(e)
+		j := i
+		for j > 0 && key(data[j-1]) > k {
+			data[j] = data[j-1]
+			j--
+		}
+		data[j] = e
+	}
+}
@@ -0,0 +1,66 @@
+package radixsort_test
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"golang.org/x/exp/constraints"
+)
+
+// testSmallInputs sorts every length around the insertion sort thresholds,
+// so that both sides of each crossover are exercised.
+func testSmallInputs[T constraints.Integer, B constraints.Unsigned](t *testing.T, sortFunc func([]T, []B) error, sortFuncName string) {
+	for n := range 300 {
+		data := make([]T, n)
+		for i := range data {
+			data[i] = T(rand.Uint64())
+		}
+		want := slices.Clone(data)
+		slices.Sort(want)
+
+		if err := sortFunc(data, make([]B, n)); err != nil {
+			t.Fatalf("%s(%d elements) failed: %v", sortFuncName, n, err)
+		}
+		if !slices.Equal(want, data) {
+			t.Fatalf("%s(%d elements) failed to sort data correctly", sortFuncName, n)
+		}
+	}
+}
+
+func TestSmallInputs(t *testing.T) {
+	testSmallInputs(t, radixsort.Uint8, "Uint8")
+	testSmallInputs(t, radixsort.Uint16, "Uint16")
+	testSmallInputs(t, radixsort.Uint32, "Uint32")
+	testSmallInputs(t, radixsort.Uint64, "Uint64")
+	testSmallInputs(t, radixsort.Int8, "Int8")
+	testSmallInputs(t, radixsort.Int16, "Int16")
+	testSmallInputs(t, radixsort.Int32, "Int32")
+	testSmallInputs(t, radixsort.Int64, "Int64")
+}
+
+func TestSmallInputsGenericStable(t *testing.T) {
+	type pair struct {
+		Key float32
+		ID  int32
+	}
+
+	for n := range 300 {
+		data := make([]pair, n)
+		for i := range data {
+			data[i] = pair{Key: float32(rand.Intn(20) - 10), ID: int32(i)}
+		}
+		want := slices.Clone(data)
+		slices.SortStableFunc(want, func(a, b pair) int { return cmp.Compare(a.Key, b.Key) })
+
+		err := radixsort.Generic(data, make([]pair, n), func(p pair) float32 { return p.Key })
+		if err != nil {
+			t.Fatalf("Generic(%d elements) failed: %v", n, err)
+		}
+		if !slices.Equal(want, data) {
+			t.Fatalf("Generic(%d elements): result is not a stable sort of the input", n)
+		}
+	}
+}
@@ -8,14 +8,33 @@ import (
 // Int16 sorts a slice of int16 values in ascending order.
 //
 // The data slice is sorted in place. The buf slice is used for temporary
-// storage during sorting and must have len(buf) >= len(data).
+// storage during sorting. A buffer shorter than the data is accepted down to
+// [MinBufferSize] elements; the sort is then slower but uses no more memory.
 //
 // The buffer can be reused across multiple sort operations without clearing.
 //
-// Returns ErrInvalidBufferSize if len(buf) < len(data).
+// Long inputs whose values span a narrow range, at least four elements per
+// possible value, are sorted by counting, with the counters kept in the
+// buffer instead of moving the elements through it.
+//
+// Returns ErrInvalidBufferSize if len(buf) < min(len(data), MinBufferSize).
 //
 // See [Int64] for the 64-bit version and for usage example.
 func Int16(data []int16, buf []uint16) error {
+	if err := checkBuffer(len(data), len(buf)); err != nil {
+		return err
+	}
+	if countingSortRange(data, counters(buf)) {
+		return nil
+	}
+	if len(buf) < len(data) {
+		unsignedData := *(*[]uint16)(unsafe.Pointer(&data))
+		return sortSignedBounded(unsignedData, buf, 1<<15, 1*8, radix16b8)
+	}
+	if isSmall(len(data), 2) {
+		insertionSort(data)
+		return nil
+	}
 	return int16ver1call(data, buf)
 }
 
@@ -8,20 +8,32 @@ import (
 // Int32 sorts a slice of int32 values in ascending order.
 //
 // The data slice is sorted in place. The buf slice is used for temporary
-// storage during sorting and must have len(buf) >= len(data).
+// storage during sorting. A buffer shorter than the data is accepted down to
+// [MinBufferSize] elements; the sort is then slower but uses no more memory.
 //
 // The buffer can be reused across multiple sort operations without clearing.
 //
-// Returns ErrInvalidBufferSize if len(buf) < len(data).
+// Returns ErrInvalidBufferSize if len(buf) < min(len(data), MinBufferSize).
 //
 // See [Int64] for the 64-bit version and for usage example.
 func Int32(data []int32, buf []uint32) error {
+	if len(buf) < len(data) {
+		unsignedData := *(*[]uint32)(unsafe.Pointer(&data))
+		return sortSignedBounded(unsignedData, buf, 1<<31, 3*8, radix32)
+	}
+	if isSmall(len(data), 4) {
+		insertionSort(data)
+		return nil
+	}
+	if sortSampled(*(*[]uint32)(unsafe.Pointer(&data)), buf, 1<<31) {
+		return nil
+	}
 	return int32ver1call(data, buf)
 }
 
 func int32ver1call(data []int32, buf []uint32) error {
 	unsignedData := *(*[]uint32)(unsafe.Pointer(&data))
-	err := radix32b8(unsignedData, buf)
+	err := radix32(unsignedData, buf)
 	if err != nil {
 		return err
 	}
@@ -8,11 +8,12 @@ import (
 // Int64 sorts a slice of int64 values in ascending order.
 //
 // The data slice is sorted in place. The buf slice is used for temporary
-// storage during sorting and must have len(buf) >= len(data).
+// storage during sorting. A buffer shorter than the data is accepted down to
+// [MinBufferSize] elements; the sort is then slower but uses no more memory.
 //
 // The buffer can be reused across multiple sort operations without clearing.
 //
-// Returns ErrInvalidBufferSize if len(buf) < len(data).
+// Returns ErrInvalidBufferSize if len(buf) < min(len(data), MinBufferSize).
 //
 // Example:
 //
@@ -21,12 +22,23 @@ import (
 //	err := Int64(data, buf)
 //	// data is now sorted: [-9, -5, 0, 1, 2]
 func Int64(data []int64, buf []uint64) error {
+	if len(buf) < len(data) {
+		unsignedData := *(*[]uint64)(unsafe.Pointer(&data))
+		return sortSignedBounded(unsignedData, buf, 1<<63, 7*8, radix64)
+	}
+	if isSmall(len(data), 8) {
+		insertionSort(data)
+		return nil
+	}
+	if sortSampled(*(*[]uint64)(unsafe.Pointer(&data)), buf, 1<<63) {
+		return nil
+	}
 	return int64ver1call(data, buf)
 }
 
 func int64ver1call(data []int64, buf []uint64) error {
 	unsignedData := *(*[]uint64)(unsafe.Pointer(&data))
-	err := radix64b8(unsignedData, buf)
+	err := radix64(unsignedData, buf)
 	if err != nil {
 		return err
 	}
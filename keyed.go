@@ -0,0 +1,150 @@
+package radixsort
+
+import (
+	"math"
+	"unsafe"
+)
+
+// Keyed is implemented by indexable containers that can be radix sorted
+// without being a Go slice, such as ring buffers, chunked arrays or
+// memory-mapped tables.
+//
+// It is the radix counterpart of sort.Interface: instead of comparing two
+// elements, each element exposes an unsigned sort key.
+// Use [OrderedKey] to turn signed integer or floating-point keys into
+// unsigned ones with the same ordering.
+type Keyed interface {
+	// Len returns the number of elements in the container.
+	Len() int
+	// Key returns the sort key of the element with index i.
+	Key(i int) uint64
+	// Swap swaps the elements with indexes i and j.
+	Swap(i, j int)
+}
+
+// Mover is implemented by [Keyed] containers that can copy an element in one
+// step, such as containers of large records. A swap costs three copies, while
+// applying a permutation with Move copies each displaced element once, plus
+// once more for the element of every cycle that waits in the spare slot.
+type Mover interface {
+	Keyed
+	// Move copies the element with index src to index dst. Either index may
+	// be -1, which denotes a spare slot for one element outside the container.
+	Move(dst, src int)
+}
+
+// Sort sorts data in ascending order of its keys.
+//
+// Key is called exactly once per element. The keys are sorted together with
+// the element indexes in an internal array, and the resulting permutation is
+// applied to the container with at most data.Len()-1 calls to Swap. If data
+// implements [Mover], the permutation is applied with Move instead, and Swap
+// is never called.
+//
+// The sort is stable. Sort allocates 32 bytes of temporary storage
+// per element.
+//
+// Example:
+//
+//	type ring struct {
+//	    items []float64
+//	    head  int
+//	}
+//
+//	func (r *ring) Len() int           { return len(r.items) }
+//	func (r *ring) at(i int) int       { return (r.head + i) % len(r.items) }
+//	func (r *ring) Key(i int) uint64   { return OrderedKey(r.items[r.at(i)]) }
+//	func (r *ring) Swap(i, j int)      { a, b := r.at(i), r.at(j); r.items[a], r.items[b] = r.items[b], r.items[a] }
+//
+//	Sort(&ring{items: values, head: 3})
+func Sort(data Keyed) {
+	n := data.Len()
+	if n < 2 {
+		return
+	}
+
+	pairs := make([]keyIndex, 2*n)
+	pairs, pairsBuf := pairs[:n], pairs[n:]
+	for i := range pairs {
+		pairs[i] = keyIndex{key: data.Key(i), idx: i}
+	}
+
+	radixKeyIndex(pairs, pairsBuf)
+	if m, ok := data.(Mover); ok {
+		movePermutation(m, pairs)
+		return
+	}
+	applyPermutation(data, pairs)
+}
+
+// applyPermutation moves the element with index perm[i].idx to position i
+// by following the cycles of the permutation.
+// Visited positions are marked by turning them into fixed points.
+func applyPermutation(data Keyed, perm []keyIndex) {
+	for i := range perm {
+		j := i
+		for perm[j].idx != i {
+			next := perm[j].idx
+			data.Swap(j, next)
+			perm[j].idx = j
+			j = next
+		}
+		perm[j].idx = j
+	}
+}
+
+// movePermutation is applyPermutation for containers that implement Mover:
+// the first element of every cycle is parked in the spare slot, the other
+// elements of the cycle are moved up, and the parked element closes it.
+func movePermutation(data Mover, perm []keyIndex) {
+	for i := range perm {
+		if perm[i].idx == i {
+			continue
+		}
+
+		data.Move(-1, i)
+		j := i
+		for perm[j].idx != i {
+			next := perm[j].idx
+			data.Move(j, next)
+			perm[j].idx = j
+			j = next
+		}
+		data.Move(j, -1)
+		perm[j].idx = j
+	}
+}
+
+// OrderedKey converts a numeric value into a uint64 key whose unsigned
+// ordering matches the ordering of the original values.
+//
+// It applies the same transformation that [Generic] uses internally:
+// signed integers have their sign bit flipped, and floats are ordered as
+// -Inf < negative values < -0 < +0 < positive values < +Inf.
+// NaNs with the sign bit set sort first, other NaNs sort last.
+//
+// The result only uses the low sizeof(N) bytes, so narrow keys sort
+// with fewer radix passes.
+//
+// Example:
+//
+//	OrderedKey(int8(-1)) < OrderedKey(int8(0))    // true
+//	OrderedKey(-2.5) < OrderedKey(math.Inf(1))    // true
+func OrderedKey[N ConstraintNumbers](v N) uint64 {
+	size := unsafe.Sizeof(v)
+	one := N(1)
+
+	switch {
+	case one/2 != 0 && size == 4:
+		k := math.Float32bits(float32(v))
+		return uint64(k ^ (uint32(int32(k)>>31) | 1<<31))
+	case one/2 != 0:
+		k := math.Float64bits(float64(v))
+		return k ^ (uint64(int64(k)>>63) | 1<<63)
+	case v-v-one < 0:
+		signBit := uint64(1) << (size*8 - 1)
+		return (uint64(int64(v)) ^ signBit) & (signBit<<1 - 1)
+	default:
+		return uint64(v)
+	}
+}
@@ -0,0 +1,214 @@
+package radixsort_test
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"github.com/google/go-cmp/cmp"
+)
+
+// ringEntry remembers its original position to make stability observable.
+type ringEntry struct {
+	value float64
+	pos   int
+}
+
+// ring is a fixed-size ring buffer whose logical index 0 starts at head.
+type ring struct {
+	items []ringEntry
+	head  int
+	swaps int
+}
+
+func (r *ring) Len() int         { return len(r.items) }
+func (r *ring) at(i int) int     { return (r.head + i) % len(r.items) }
+func (r *ring) Key(i int) uint64 { return radixsort.OrderedKey(r.items[r.at(i)].value) }
+func (r *ring) Swap(i, j int) {
+	a, b := r.at(i), r.at(j)
+	r.items[a], r.items[b] = r.items[b], r.items[a]
+	r.swaps++
+}
+
+// movingRing is a ring that implements radixsort.Mover.
+type movingRing struct {
+	ring
+	spare ringEntry
+	moves int
+}
+
+func (r *movingRing) Move(dst, src int) {
+	e := r.spare
+	if src >= 0 {
+		e = r.items[r.at(src)]
+	}
+	if dst >= 0 {
+		r.items[r.at(dst)] = e
+	} else {
+		r.spare = e
+	}
+	r.moves++
+}
+
+func (r *ring) logical() []ringEntry {
+	out := make([]ringEntry, len(r.items))
+	for i := range out {
+		out[i] = r.items[r.at(i)]
+	}
+	return out
+}
+
+func TestSortKeyed(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []float64
+		head int
+		want []float64
+	}{
+		{
+			name: "empty",
+			in:   []float64{},
+			want: []float64{},
+		},
+		{
+			name: "single element",
+			in:   []float64{4.2},
+			want: []float64{4.2},
+		},
+		{
+			name: "already sorted",
+			in:   []float64{-1, 0, 1, 2},
+			head: 2,
+			want: []float64{-1, 0, 1, 2},
+		},
+		{
+			name: "reverse order wrapped",
+			in:   []float64{5, 4, 3, 2, 1},
+			head: 3,
+			want: []float64{1, 2, 3, 4, 5},
+		},
+		{
+			name: "mixed signs",
+			in:   []float64{10.1, -5.05, math.Inf(-1), 0, math.MaxFloat64, -20.1},
+			head: 1,
+			want: []float64{math.Inf(-1), -20.1, -5.05, 0, 10.1, math.MaxFloat64},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ring{head: tt.head}
+			for _, v := range tt.in {
+				r.items = append(r.items, ringEntry{value: v})
+			}
+			// Store the values in logical order starting at head.
+			for i, v := range tt.in {
+				r.items[r.at(i)] = ringEntry{value: v, pos: i}
+			}
+
+			radixsort.Sort(r)
+
+			got := []float64{}
+			for _, e := range r.logical() {
+				got = append(got, e.value)
+			}
+
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("case: %s; Sort(%v) = %v, want %v", tt.name, tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortKeyedStableRandom(t *testing.T) {
+	const size = 100_000
+
+	r := &ring{items: make([]ringEntry, size), head: size / 3}
+	for i := range size {
+		r.items[r.at(i)] = ringEntry{value: float64(rand.Intn(100) - 50), pos: i}
+	}
+
+	radixsort.Sort(r)
+
+	got := r.logical()
+	isStable := slices.IsSortedFunc(got, func(a, b ringEntry) int {
+		if a.value != b.value {
+			if a.value < b.value {
+				return -1
+			}
+			return 1
+		}
+		return a.pos - b.pos
+	})
+	if !isStable {
+		t.Errorf("Sort: result is not a stable sort of the input")
+	}
+
+	if r.swaps >= size {
+		t.Errorf("Sort: %d swaps for %d elements, want less than %d", r.swaps, size, size)
+	}
+}
+
+func TestSortMover(t *testing.T) {
+	const size = 100_000
+
+	r := &movingRing{ring: ring{items: make([]ringEntry, size), head: size / 3}}
+	for i := range size {
+		r.items[r.at(i)] = ringEntry{value: float64(rand.Intn(100) - 50), pos: i}
+	}
+	want := r.logical()
+	slices.SortStableFunc(want, func(a, b ringEntry) int {
+		if a.value < b.value {
+			return -1
+		}
+		if a.value > b.value {
+			return 1
+		}
+		return 0
+	})
+
+	radixsort.Sort(r)
+
+	if got := r.logical(); !slices.Equal(want, got) {
+		t.Errorf("Sort: result is not a stable sort of the input")
+	}
+	if r.swaps != 0 {
+		t.Errorf("Sort: %d swaps, want 0 for a Mover", r.swaps)
+	}
+	// Every element is moved at most once, plus once more for the first
+	// element of each cycle, of which there are at most size/2.
+	if r.moves > size+size/2 {
+		t.Errorf("Sort: %d moves for %d elements, want at most %d", r.moves, size, size+size/2)
+	}
+}
+
+func TestOrderedKey(t *testing.T) {
+	int8s := []int8{math.MinInt8, -1, 0, 1, math.MaxInt8}
+	int64s := []int64{math.MinInt64, -1, 0, 1, math.MaxInt64}
+	uint16s := []uint16{0, 1, 255, 256, math.MaxUint16}
+	float32s := []float32{float32(math.Inf(-1)), -math.MaxFloat32, -1, float32(math.Copysign(0, -1)), 0, 1e-30, float32(math.Inf(1))}
+	float64s := []float64{math.Inf(-1), -math.MaxFloat64, -1, math.Copysign(0, -1), 0, 1e-300, math.Inf(1)}
+
+	checkOrderedKeys(t, "int8", int8s)
+	checkOrderedKeys(t, "int64", int64s)
+	checkOrderedKeys(t, "uint16", uint16s)
+	checkOrderedKeys(t, "float32", float32s)
+	checkOrderedKeys(t, "float64", float64s)
+
+	if k := radixsort.OrderedKey(int16(-1)); k > math.MaxUint16 {
+		t.Errorf("OrderedKey(int16(-1)) = %#x, want a key within 16 bits", k)
+	}
+}
+
+func checkOrderedKeys[N radixsort.ConstraintNumbers](t *testing.T, name string, values []N) {
+	t.Helper()
+
+	for i := 1; i < len(values); i++ {
+		a, b := radixsort.OrderedKey(values[i-1]), radixsort.OrderedKey(values[i])
+		if a >= b {
+			t.Errorf("OrderedKey[%s](%v) = %#x, not less than OrderedKey(%v) = %#x", name, values[i-1], a, values[i], b)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package radixsort
+
+// keyIndex pairs a radix key with the position of the element it was taken from.
+type keyIndex struct {
+	key uint64
+	idx int
+}
+
+// orderedKeyFunc converts a numeric key extractor into one returning uint64
+// values with the same ordering as the original keys (see [OrderedKey]).
+func orderedKeyFunc[E any, N ConstraintNumbers](key func(a E) N) func(a E) uint64 {
+	return func(a E) uint64 {
+		return OrderedKey(key(a))
+	}
+}
+
+// radixKeyIndex sorts key/index pairs by key using 8-bit buckets.
+// It mirrors radix64b8 and keeps pairs with equal keys in their original order.
+// The buffer length must be at least as large as data.
+func radixKeyIndex(data, buf []keyIndex) {
+	// offsets[d][b] stores prefix sums (insertion offsets) for digit d and offsets b.
+	// First they are used as frequency counters, then converted into offsets.
+	offsets := [8][256]uint{}
+	for _, p := range data {
+		v := p.key
+		offsets[0][uint8(v>>(0*8))]++
+		offsets[1][uint8(v>>(1*8))]++
+		offsets[2][uint8(v>>(2*8))]++
+		offsets[3][uint8(v>>(3*8))]++
+		offsets[4][uint8(v>>(4*8))]++
+		offsets[5][uint8(v>>(5*8))]++
+		offsets[6][uint8(v>>(6*8))]++
+		offsets[7][uint8(v>>(7*8))]++
+	}
+
+	// Convert counts into prefix sums (offsets).
+	acc := [8]uint{}
+	for d := range 8 {
+		for i := range 256 {
+			offsets[d][i], acc[d] = acc[d], acc[d]+offsets[d][i]
+		}
+	}
+
+	swaps := 0
+	src, dst := data, buf[:len(data)]
+	for d := range 8 {
+		// Skip the pass when every key has the same byte in this digit.
+		if digitIsConstant(&offsets[d], acc[d]) {
+			continue
+		}
+		swaps++
+
+		for _, p := range src {
+			index := offsets[d][uint8(p.key>>(d*8))]
+			dst[index] = p
+			offsets[d][uint8(p.key>>(d*8))]++
+		}
+		src, dst = dst, src
+	}
+
+	if swaps&1 == 1 {
+		copy(data, src)
+	}
+}
+
+// digitIsConstant reports whether a single bucket of the prefix sums holds
+// all acc elements, i.e. every element shares the same byte in this digit.
+func digitIsConstant(offsets *[256]uint, acc uint) bool {
+	for b := range 256 {
+		next := acc
+		if b < 255 {
+			next = offsets[b+1]
+		}
+		if next-offsets[b] == acc {
+			return true
+		}
+		if next != offsets[b] {
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,423 @@
+package radixsort
+
+import (
+	"runtime"
+	"sync"
+	"unsafe"
+
+	"github.com/sagernet/sing/common/x/constraints"
+)
+
+// DefaultParallelThreshold is the shortest input that [Parallel] sorts on
+// several goroutines when its Threshold is zero, unless a tuning profile sets
+// another (see [Profile]). Below it, starting the workers and merging their
+// histograms costs more than the passes save.
+const DefaultParallelThreshold = 1 << 18
+
+// parallelThreshold is the threshold of a Parallel without its own, set by
+// the tuning profile (see [SetProfile]).
+var parallelThreshold = newThreshold(DefaultParallelThreshold)
+
+// Parallel sorts large inputs with an LSD radix sort spread over several
+// goroutines.
+//
+// The input is split into one contiguous chunk per worker. For every radix
+// pass, each worker counts the digits of its chunk; the per-worker
+// histograms are then turned into offsets so that worker w writes every
+// bucket right after the elements of the same bucket from workers 0..w-1.
+// The workers finally scatter their chunks concurrently. Since the chunks
+// are in order and each worker keeps the order within its chunk, the sort is
+// stable, and its result is identical to the sequential sort.
+//
+// Inputs larger than the cache are instead partitioned by their most
+// significant byte first, and the buckets, which then fit into the cache, are
+// sorted by the workers independently.
+//
+// The zero value is ready to use: it runs runtime.GOMAXPROCS(0) workers on
+// inputs of at least [DefaultParallelThreshold] elements. Shorter inputs,
+// a single worker or a buffer shorter than the data fall back to the
+// sequential sort of the same type, including its bounded memory mode.
+//
+// Example:
+//
+//	p := radixsort.Parallel{Workers: 16}
+//	buf := make([]uint64, len(data))
+//	err := p.Uint64(data, buf)
+type Parallel struct {
+	// Workers is the number of goroutines; zero or less means
+	// runtime.GOMAXPROCS(0).
+	Workers int
+	// Threshold is the shortest input that is sorted in parallel; zero or
+	// less means the ParallelThreshold of the tuning profile, which is
+	// DefaultParallelThreshold unless changed with SetProfile.
+	Threshold int
+}
+
+// workers returns the number of goroutines used to sort n elements, or 1 if
+// they are sorted sequentially.
+func (p Parallel) workers(n int) int {
+	threshold := p.Threshold
+	if threshold <= 0 {
+		threshold = parallelThreshold.get()
+	}
+	if n < threshold {
+		return 1
+	}
+
+	workers := p.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return max(min(workers, n), 1)
+}
+
+// Uint64 sorts a slice of uint64 values like [Uint64], on several
+// goroutines if the input is long enough.
+func (p Parallel) Uint64(data, buf []uint64) error {
+	workers := p.workers(len(data))
+	if workers < 2 || len(buf) < len(data) {
+		return Uint64(data, buf)
+	}
+
+	if len(data) >= hybridMinLen.get() {
+		hybrid64(data, buf, 0, 7*8, workers)
+		return nil
+	}
+	parallelRadix(data, buf, workers, 0)
+	return nil
+}
+
+// Uint32 sorts a slice of uint32 values like [Uint32], on several
+// goroutines if the input is long enough.
+func (p Parallel) Uint32(data, buf []uint32) error {
+	workers := p.workers(len(data))
+	if workers < 2 || len(buf) < len(data) {
+		return Uint32(data, buf)
+	}
+
+	parallelRadix(data, buf, workers, 0)
+	return nil
+}
+
+// Int64 sorts a slice of int64 values like [Int64], on several goroutines if
+// the input is long enough.
+func (p Parallel) Int64(data []int64, buf []uint64) error {
+	workers := p.workers(len(data))
+	if workers < 2 || len(buf) < len(data) {
+		return Int64(data, buf)
+	}
+
+	// Flipping the sign bit of the digits orders negative values first, so
+	// the elements need no rotation afterwards.
+	unsignedData := *(*[]uint64)(unsafe.Pointer(&data))
+	if len(data) >= hybridMinLen.get() {
+		// v-(1<<63) flips the sign bit as well.
+		hybrid64(unsignedData, buf, 1<<63, 7*8, workers)
+		return nil
+	}
+	parallelRadix(unsignedData, buf, workers, 1<<63)
+	return nil
+}
+
+// Int32 sorts a slice of int32 values like [Int32], on several goroutines if
+// the input is long enough.
+func (p Parallel) Int32(data []int32, buf []uint32) error {
+	workers := p.workers(len(data))
+	if workers < 2 || len(buf) < len(data) {
+		return Int32(data, buf)
+	}
+
+	unsignedData := *(*[]uint32)(unsafe.Pointer(&data))
+	parallelRadix(unsignedData, buf, workers, 1<<31)
+	return nil
+}
+
+// Float64 sorts a slice of float64 values in the order of [OrderedKey]:
+// -Inf < negative values < -0 < +0 < positive values < +Inf, with NaNs first
+// or last depending on their sign bit. Shorter inputs are sorted on the
+// calling goroutine.
+//
+// The values are converted into their keys in place, sorted as uint64 and
+// converted back, so buf holds uint64 values as for [Int64].
+//
+// Returns ErrInvalidBufferSize if len(buf) < min(len(data), MinBufferSize).
+func (p Parallel) Float64(data []float64, buf []uint64) error {
+	if err := checkBuffer(len(data), len(buf)); err != nil {
+		return err
+	}
+
+	keys := *(*[]uint64)(unsafe.Pointer(&data))
+	workers := p.workers(len(data))
+	floatKeys(keys, workers)
+	// The buffer has been checked; the sort of the keys cannot fail.
+	_ = p.Uint64(keys, buf)
+	floatValues(keys, workers)
+	return nil
+}
+
+// Float32 sorts a slice of float32 values like [Parallel.Float64].
+func (p Parallel) Float32(data []float32, buf []uint32) error {
+	if err := checkBuffer(len(data), len(buf)); err != nil {
+		return err
+	}
+
+	keys := *(*[]uint32)(unsafe.Pointer(&data))
+	workers := p.workers(len(data))
+	floatKeys(keys, workers)
+	_ = p.Uint32(keys, buf)
+	floatValues(keys, workers)
+	return nil
+}
+
+// GenericParallel sorts a slice of elements by a numeric key like [Generic],
+// on the goroutines configured by p if the input is long enough. It is
+// a function rather than a method of [Parallel] because Go methods cannot
+// have type parameters.
+//
+// The sort is stable. As with Generic, the key function is called once per
+// element per sorting pass, unless the elements to move are large enough for
+// the sort to switch to (key, index) pairs, which allocates.
+//
+// Returns ErrInvalidBufferSize if len(buf) < min(len(data), MinBufferSize).
+//
+// Example:
+//
+//	p := radixsort.Parallel{Workers: 8}
+//	err := radixsort.GenericParallel(p, items, buf, func(i Item) float64 {
+//	    return i.Score
+//	})
+func GenericParallel[E any, N ConstraintNumbers](p Parallel, data, buf []E, key func(a E) N) error {
+	workers := p.workers(len(data))
+	if workers < 2 || len(buf) < len(data) {
+		return Generic(data, buf, key)
+	}
+
+	var keyZeroValue N
+	digits := int(unsafe.Sizeof(keyZeroValue))
+	unsignedKey := orderedKeyFunc(key)
+
+	counts := parallelCounts(data, workers, digits, unsignedKey)
+	active := activeDigits(counts, digits)
+
+	var elemZeroValue E
+	if !cachedKeyPays(unsafe.Sizeof(elemZeroValue), len(active)) {
+		parallelRadixFunc(data, buf, workers, counts, active, unsignedKey)
+		return nil
+	}
+
+	// The pairs have the keys of the elements, so the histograms of the
+	// elements serve for the pairs as well.
+	pairs := make([]keyIndex, 2*len(data))
+	pairs, pairsBuf := pairs[:len(data)], pairs[len(data):]
+	parallelChunks(len(data), workers, func(_, lo, hi int) {
+		for i, e := range data[lo:hi] {
+			pairs[lo+i] = keyIndex{key: unsignedKey(e), idx: lo + i}
+		}
+	})
+
+	parallelRadixFunc(pairs, pairsBuf, workers, counts, active, func(p keyIndex) uint64 { return p.key })
+
+	parallelChunks(len(data), workers, func(_, lo, hi int) {
+		for i, p := range pairs[lo:hi] {
+			buf[lo+i] = data[p.idx]
+		}
+	})
+	parallelChunks(len(data), workers, func(_, lo, hi int) {
+		copy(data[lo:hi], buf[lo:hi])
+	})
+	return nil
+}
+
+// floatKeys converts the bits of IEEE 754 floats in place into keys that
+// order like [OrderedKey], on workers goroutines.
+func floatKeys[T uint32 | uint64](keys []T, workers int) {
+	signBit := T(1) << (unsafe.Sizeof(T(0))*8 - 1)
+	parallelChunks(len(keys), workers, func(_, lo, hi int) {
+		for i, k := range keys[lo:hi] {
+			// Negative values have all bits flipped, others the sign bit.
+			if k&signBit != 0 {
+				keys[lo+i] = ^k
+			} else {
+				keys[lo+i] = k | signBit
+			}
+		}
+	})
+}
+
+// floatValues reverts floatKeys.
+func floatValues[T uint32 | uint64](keys []T, workers int) {
+	signBit := T(1) << (unsafe.Sizeof(T(0))*8 - 1)
+	parallelChunks(len(keys), workers, func(_, lo, hi int) {
+		for i, k := range keys[lo:hi] {
+			// Keys of non-negative values have the top bit set.
+			if k&signBit != 0 {
+				keys[lo+i] = k &^ signBit
+			} else {
+				keys[lo+i] = ^k
+			}
+		}
+	})
+}
+
+// parallelChunks splits n elements into workers contiguous chunks and calls
+// fn for chunk w, holding the elements lo to hi, on its own goroutine.
+// It returns when all calls have returned.
+func parallelChunks(n, workers int, fn func(w, lo, hi int)) {
+	if workers < 2 {
+		fn(0, 0, n)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workers - 1)
+	for w := 1; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			fn(w, n*w/workers, n*(w+1)/workers)
+		}()
+	}
+	fn(0, 0, n/workers)
+	wg.Wait()
+}
+
+// parallelRadix performs an LSD radix sort with 8-bit digits of v^flip on
+// workers goroutines. Digits whose elements all fall into one bucket are
+// skipped. The buffer length must be at least as large as data.
+func parallelRadix[T constraints.Unsigned](data, buf []T, workers int, flip T) {
+	digits := int(unsafe.Sizeof(flip))
+
+	// counts[w][d] is the histogram of digit d in the chunk of worker w.
+	// The first pass counts all digits; after a scatter pass the chunks hold
+	// other elements, so only the digit of the next pass is recounted.
+	counts := make([][8][256]uint, workers)
+	parallelChunks(len(data), workers, func(w, lo, hi int) {
+		c := &counts[w]
+		for _, v := range data[lo:hi] {
+			v ^= flip
+			for d := range digits {
+				c[d][uint8(v>>(d*8))]++
+			}
+		}
+	})
+
+	offsets := make([][256]uint, workers)
+	src, dst := data, buf[:len(data)]
+	for i, d := range activeDigits(counts, digits) {
+		shift := uint(d * 8)
+		if i > 0 {
+			parallelChunks(len(data), workers, func(w, lo, hi int) {
+				c := &counts[w][d]
+				*c = [256]uint{}
+				for _, v := range src[lo:hi] {
+					c[uint8((v^flip)>>shift)]++
+				}
+			})
+		}
+
+		parallelOffsets(counts, d, offsets)
+		parallelChunks(len(data), workers, func(w, lo, hi int) {
+			o := &offsets[w]
+			for _, v := range src[lo:hi] {
+				b := uint8((v ^ flip) >> shift)
+				dst[o[b]] = v
+				o[b]++
+			}
+		})
+		src, dst = dst, src
+	}
+
+	if len(data) > 0 && &src[0] != &data[0] {
+		parallelChunks(len(data), workers, func(_, lo, hi int) {
+			copy(data[lo:hi], src[lo:hi])
+		})
+	}
+}
+
+// parallelCounts returns the per-worker histograms of the low digits bytes of
+// key over data, as parallelRadixFunc expects them.
+func parallelCounts[E any](data []E, workers, digits int, key func(a E) uint64) [][8][256]uint {
+	counts := make([][8][256]uint, workers)
+	parallelChunks(len(data), workers, func(w, lo, hi int) {
+		c := &counts[w]
+		for _, e := range data[lo:hi] {
+			k := key(e)
+			for d := range digits {
+				c[d][uint8(k>>(d*8))]++
+			}
+		}
+	})
+	return counts
+}
+
+// parallelRadixFunc is parallelRadix for elements sorted by the active digits
+// of key, given the per-worker histograms counts of data (see
+// [parallelCounts] and [activeDigits]).
+func parallelRadixFunc[E any](data, buf []E, workers int, counts [][8][256]uint, active []int, key func(a E) uint64) {
+	offsets := make([][256]uint, workers)
+	src, dst := data, buf[:len(data)]
+	for i, d := range active {
+		shift := uint(d * 8)
+		if i > 0 {
+			parallelChunks(len(data), workers, func(w, lo, hi int) {
+				c := &counts[w][d]
+				*c = [256]uint{}
+				for _, e := range src[lo:hi] {
+					c[uint8(key(e)>>shift)]++
+				}
+			})
+		}
+
+		parallelOffsets(counts, d, offsets)
+		parallelChunks(len(data), workers, func(w, lo, hi int) {
+			o := &offsets[w]
+			for _, e := range src[lo:hi] {
+				b := uint8(key(e) >> shift)
+				dst[o[b]] = e
+				o[b]++
+			}
+		})
+		src, dst = dst, src
+	}
+
+	if len(data) > 0 && &src[0] != &data[0] {
+		parallelChunks(len(data), workers, func(_, lo, hi int) {
+			copy(data[lo:hi], src[lo:hi])
+		})
+	}
+}
+
+// activeDigits returns the digits below digits whose elements do not all fall
+// into one bucket, given the per-worker histograms of the input. Whether
+// a digit is constant does not depend on the order of the elements, so the
+// result holds for every pass.
+func activeDigits(counts [][8][256]uint, digits int) []int {
+	active := make([]int, 0, digits)
+	for d := range digits {
+		n, largest := uint(0), uint(0)
+		for b := range 256 {
+			total := uint(0)
+			for w := range counts {
+				total += counts[w][d][b]
+			}
+			n += total
+			largest = max(largest, total)
+		}
+		if largest < n {
+			active = append(active, d)
+		}
+	}
+	return active
+}
+
+// parallelOffsets computes from the per-worker histograms of digit d the
+// offset at which every worker writes each bucket: the buckets are laid out
+// in order, and within a bucket the workers in the order of their chunks.
+func parallelOffsets(counts [][8][256]uint, d int, offsets [][256]uint) {
+	acc := uint(0)
+	for b := range 256 {
+		for w := range counts {
+			offsets[w][b] = acc
+			acc += counts[w][d][b]
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package radixsort_test
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+// eager sorts every input in parallel, so that short test inputs exercise the
+// parallel kernels; the odd worker count leaves chunks of unequal length.
+var eager = radixsort.Parallel{Workers: 3, Threshold: 1}
+
+func TestParallelUnsigned(t *testing.T) {
+	testUnsignedSort(t, eager.Uint32, "Parallel.Uint32")
+	testUnsignedSort(t, eager.Uint64, "Parallel.Uint64")
+	testUnsignedSortLargeRandom(t, eager.Uint32, "Parallel.Uint32")
+	testUnsignedSortLargeRandom(t, eager.Uint64, "Parallel.Uint64")
+	testSortBufferSize(t, eager.Uint32, "Parallel.Uint32")
+	testSortBufferSize(t, eager.Uint64, "Parallel.Uint64")
+}
+
+func TestParallelSigned(t *testing.T) {
+	testSignedSort(t, eager.Int32, "Parallel.Int32")
+	testSignedSort(t, eager.Int64, "Parallel.Int64")
+	testSignedSortLargeRandom(t, eager.Int32, "Parallel.Int32")
+	testSignedSortLargeRandom(t, eager.Int64, "Parallel.Int64")
+	testSortBufferSize(t, eager.Int32, "Parallel.Int32")
+	testSortBufferSize(t, eager.Int64, "Parallel.Int64")
+}
+
+func TestParallelWorkers(t *testing.T) {
+	input := make([]uint64, 10_000)
+	for i := range input {
+		// Constant high bytes exercise the skipped passes.
+		input[i] = rand.Uint64() & 0xff_00ff_ffff
+	}
+	want := slices.Clone(input)
+	slices.Sort(want)
+
+	for _, p := range []radixsort.Parallel{
+		{},
+		{Workers: 1, Threshold: 1},
+		{Workers: 2, Threshold: 1},
+		{Workers: 7, Threshold: 1},
+		// More workers than elements.
+		{Workers: 20_000, Threshold: 1},
+		{Workers: 4, Threshold: len(input) + 1},
+	} {
+		data := slices.Clone(input)
+		if err := p.Uint64(data, make([]uint64, len(data))); err != nil {
+			t.Fatalf("%+v: %v", p, err)
+		}
+		if !slices.Equal(want, data) {
+			t.Errorf("%+v failed to sort data correctly", p)
+		}
+	}
+}
+
+func TestParallelFloat(t *testing.T) {
+	special := []float64{
+		math.Inf(1), math.Inf(-1), math.Copysign(0, -1), 0,
+		math.SmallestNonzeroFloat64, -math.MaxFloat64, math.MaxFloat64,
+	}
+	input := make([]float64, 50_000)
+	for i := range input {
+		if i < len(special) {
+			input[i] = special[i]
+			continue
+		}
+		input[i] = rand.NormFloat64() * 1e6
+	}
+
+	wantKeys := func(data []float64) []uint64 {
+		keys := make([]uint64, len(data))
+		for i, v := range data {
+			keys[i] = radixsort.OrderedKey(v)
+		}
+		return keys
+	}
+	want := slices.Clone(input)
+	slices.SortFunc(want, func(a, b float64) int {
+		return cmp.Compare(radixsort.OrderedKey(a), radixsort.OrderedKey(b))
+	})
+
+	for _, p := range []radixsort.Parallel{eager, {}} {
+		data := slices.Clone(input)
+		if err := p.Float64(data, make([]uint64, len(data))); err != nil {
+			t.Fatalf("Float64: %v", err)
+		}
+		// Compare the bits to tell -0 from +0.
+		if !slices.Equal(wantKeys(want), wantKeys(data)) {
+			t.Errorf("%+v: Float64 failed to sort data correctly", p)
+		}
+
+		data32 := make([]float32, len(input))
+		for i, v := range input {
+			data32[i] = float32(v)
+		}
+		if err := p.Float32(data32, make([]uint32, len(data32))); err != nil {
+			t.Fatalf("Float32: %v", err)
+		}
+		if !slices.IsSortedFunc(data32, func(a, b float32) int {
+			return cmp.Compare(radixsort.OrderedKey(a), radixsort.OrderedKey(b))
+		}) {
+			t.Errorf("%+v: Float32 failed to sort data correctly", p)
+		}
+	}
+
+	data := slices.Clone(input)
+	if err := eager.Float64(data, make([]uint64, 10)); err == nil {
+		t.Error("Float64 accepted a buffer below MinBufferSize")
+	}
+}
+
+func TestGenericParallelStable(t *testing.T) {
+	type small struct {
+		key int16
+		seq int32
+	}
+	type large struct {
+		key float64
+		seq int
+		pad [2]int
+	}
+
+	const size = 30_000
+	smalls := make([]small, size)
+	larges := make([]large, size)
+	for i := range size {
+		// Few distinct keys, so that most elements have equal neighbours.
+		k := rand.Intn(200) - 100
+		smalls[i] = small{key: int16(k), seq: int32(i)}
+		larges[i] = large{key: float64(k) / 4, seq: i}
+	}
+
+	wantSmall := slices.Clone(smalls)
+	slices.SortStableFunc(wantSmall, func(a, b small) int { return cmp.Compare(a.key, b.key) })
+	err := radixsort.GenericParallel(eager, smalls, make([]small, size), func(e small) int16 { return e.key })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(wantSmall, smalls) {
+		t.Error("GenericParallel is not stable on small elements")
+	}
+
+	wantLarge := slices.Clone(larges)
+	slices.SortStableFunc(wantLarge, func(a, b large) int { return cmp.Compare(a.key, b.key) })
+	err = radixsort.GenericParallel(eager, larges, make([]large, size), func(e large) float64 { return e.key })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(wantLarge, larges) {
+		t.Error("GenericParallel is not stable on large elements")
+	}
+}
@@ -0,0 +1,94 @@
+package radixsort
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Maximum numbers of ascending runs that are merged instead of radix sorted,
+// by key width. Merging r runs takes about log2(r) passes over the data,
+// which must stay well below the number of radix passes to pay off, because
+// a merge pass branches on every comparison.
+const (
+	mergeRunsMax16 = 2
+	mergeRunsMax32 = 4
+	mergeRunsMax64 = 8
+)
+
+// sortPresorted finishes the sort of data if it is cheaper than radix
+// sorting, given the number of descents: positions i where
+// data[i] < data[i-1], counted during the histogram pass.
+//
+// Sorted data is left as is, strictly descending data is reversed, which is
+// stable because no two elements are equal, and data that consists of at
+// most maxRuns ascending runs is merged stably through buf. It reports
+// whether data has been sorted. The buffer length must be at least as large
+// as data.
+func sortPresorted[T cmp.Ordered](data, buf []T, descents, maxRuns int) bool {
+	switch {
+	case descents == 0:
+		return true
+	case descents == len(data)-1:
+		slices.Reverse(data)
+		return true
+	case descents < maxRuns:
+		mergeRuns(data, buf, descents+1)
+		return true
+	}
+	return false
+}
+
+// mergeRuns stably sorts data that consists of the given number of ascending
+// runs, at most mergeRunsMax64, by merging neighbouring runs pairwise.
+func mergeRuns[T cmp.Ordered](data, buf []T, runs int) {
+	// bounds[r] is the start of run r; bounds[runs] is the end of data.
+	var bounds [mergeRunsMax64 + 1]int
+	r := 1
+	for i := 1; i < len(data); i++ {
+		if data[i] < data[i-1] {
+			bounds[r] = i
+			r++
+		}
+	}
+	bounds[r] = len(data)
+
+	src, dst := data, buf[:len(data)]
+	for ; runs > 1; runs = (runs + 1) / 2 {
+		for r := 0; r < runs; r += 2 {
+			lo := bounds[r]
+			if r+1 == runs {
+				// An odd run out is carried over to the next round.
+				copy(dst[lo:], src[lo:bounds[r+1]])
+				bounds[r/2] = lo
+				continue
+			}
+			mid, hi := bounds[r+1], bounds[r+2]
+			mergeInto(dst[lo:hi], src[lo:mid], src[mid:hi])
+			bounds[r/2] = lo
+		}
+		bounds[(runs+1)/2] = len(data)
+		src, dst = dst, src
+	}
+
+	if &src[0] != &data[0] {
+		copy(data, src)
+	}
+}
+
+// mergeInto merges the ascending slices a and b into dst, taking elements of
+// a first when they are equal.
+func mergeInto[T cmp.Ordered](dst, a, b []T) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if b[j] < a[i] {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
@@ -0,0 +1,81 @@
+package radixsort_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"golang.org/x/exp/constraints"
+)
+
+// presortedInputs returns inputs of the given size that are sorted, strictly
+// descending, descending with ties, or made of a few ascending runs.
+func presortedInputs[T constraints.Integer](size int) map[string][]T {
+	inputs := map[string][]T{}
+
+	sorted := make([]T, size)
+	for i := range sorted {
+		sorted[i] = T(rand.Uint64())
+	}
+	slices.Sort(sorted)
+	inputs["sorted"] = sorted
+
+	descending := make([]T, size)
+	for i := range descending {
+		descending[i] = T(size - i)
+	}
+	inputs["strictly descending"] = descending
+
+	ties := make([]T, size)
+	for i := range ties {
+		ties[i] = T((size - i) / 3)
+	}
+	inputs["descending with ties"] = ties
+
+	for _, runs := range []int{2, 3, 4, 5, 8, 9} {
+		data := make([]T, 0, size)
+		for r := range runs {
+			run := make([]T, size/runs+r)
+			for i := range run {
+				run[i] = T(rand.Uint64())
+			}
+			slices.Sort(run)
+			data = append(data, run...)
+		}
+		inputs[fmt.Sprintf("%d runs", runs)] = data
+	}
+
+	return inputs
+}
+
+func testPresorted[T constraints.Integer, B constraints.Unsigned](t *testing.T, sortFunc func([]T, []B) error, sortFuncName string) {
+	// The longer size is sorted by the 11-bit kernel of the 32-bit types.
+	restore := radixsort.SetWideDigitMinLen(1 << 16)
+	defer restore()
+
+	for _, size := range []int{10_000, 1 << 16} {
+		for name, input := range presortedInputs[T](size) {
+			data := slices.Clone(input)
+			want := slices.Clone(input)
+			slices.Sort(want)
+
+			if err := sortFunc(data, make([]B, len(data))); err != nil {
+				t.Fatalf("%s(%s, %d) failed: %v", sortFuncName, name, size, err)
+			}
+			if !slices.Equal(want, data) {
+				t.Errorf("%s failed to sort %s input of %d elements", sortFuncName, name, size)
+			}
+		}
+	}
+}
+
+func TestPresorted(t *testing.T) {
+	testPresorted(t, radixsort.Uint16, "Uint16")
+	testPresorted(t, radixsort.Uint32, "Uint32")
+	testPresorted(t, radixsort.Uint64, "Uint64")
+	testPresorted(t, radixsort.Int16, "Int16")
+	testPresorted(t, radixsort.Int32, "Int32")
+	testPresorted(t, radixsort.Int64, "Int64")
+}
@@ -0,0 +1,189 @@
+package radixsort
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// ProfileEnv is the environment variable naming a tuning profile file that
+// the package loads during initialization, see [LoadEnvProfile].
+const ProfileEnv = "RADIXSORT_PROFILE"
+
+// Profile holds the machine-dependent thresholds of the sorts.
+//
+// The built-in thresholds were measured on a few common CPUs. Where the cache
+// sizes, memory bandwidth or core count differ, [Calibrate] (or the radixtune
+// command) measures them on the local machine; the result is saved with
+// [Profile.WriteFile] and loaded with [LoadProfile] or through the
+// environment variable [ProfileEnv].
+//
+// A zero field stands for the built-in value, so a profile can set some
+// thresholds only. The file format is JSON with the field names below; fields
+// unknown to this version of the package are ignored.
+type Profile struct {
+	// Machine describes the machine the profile was measured on. It is
+	// informational only.
+	Machine string `json:"machine,omitempty"`
+
+	// SmallSortMax8 to SmallSortMax64 are the longest inputs of 8- to 64-bit
+	// keys sorted with insertion sort instead of radix sort.
+	SmallSortMax8  int `json:"small_sort_max_8,omitempty"`
+	SmallSortMax16 int `json:"small_sort_max_16,omitempty"`
+	SmallSortMax32 int `json:"small_sort_max_32,omitempty"`
+	SmallSortMax64 int `json:"small_sort_max_64,omitempty"`
+
+	// WideDigitMinLen is the shortest input of 32-bit keys sorted with 11-bit
+	// digits.
+	WideDigitMinLen int `json:"wide_digit_min_len,omitempty"`
+	// CombinedScatterMinLen is the shortest input of 64-bit keys whose
+	// scatter passes are write-combined.
+	CombinedScatterMinLen int `json:"combined_scatter_min_len,omitempty"`
+	// HybridMinLen is the shortest input of 64-bit keys that is partitioned
+	// into cache-sized buckets before the LSD passes.
+	HybridMinLen int `json:"hybrid_min_len,omitempty"`
+	// ParallelThreshold is the shortest input sorted on several goroutines
+	// by a [Parallel] whose Threshold is zero.
+	ParallelThreshold int `json:"parallel_threshold,omitempty"`
+}
+
+// threshold is a tunable threshold of the sorts. A profile may replace it
+// while other goroutines sort, so it is stored atomically. Thresholds only
+// choose between algorithms that give the same result, so a sort that sees
+// both values of a changing threshold is still correct.
+type threshold struct {
+	n atomic.Int64
+}
+
+func newThreshold(n int) *threshold {
+	t := new(threshold)
+	t.set(n)
+	return t
+}
+
+func (t *threshold) get() int {
+	return int(t.n.Load())
+}
+
+func (t *threshold) set(n int) {
+	t.n.Store(int64(n))
+}
+
+// builtinProfile holds the thresholds the package starts with.
+var builtinProfile = CurrentProfile()
+
+// ErrInvalidProfile is returned by [SetProfile] and [LoadProfile] for
+// a profile with a negative threshold. The returned error wraps
+// ErrInvalidProfile and names the field.
+var ErrInvalidProfile = errors.New("invalid tuning profile")
+
+// CurrentProfile returns the thresholds in effect, with every field set.
+func CurrentProfile() Profile {
+	return Profile{
+		SmallSortMax8:         smallSortMax[1].get(),
+		SmallSortMax16:        smallSortMax[2].get(),
+		SmallSortMax32:        smallSortMax[4].get(),
+		SmallSortMax64:        smallSortMax[8].get(),
+		WideDigitMinLen:       wideDigitMinLen.get(),
+		CombinedScatterMinLen: combinedScatterMinLen.get(),
+		HybridMinLen:          hybridMinLen.get(),
+		ParallelThreshold:     parallelThreshold.get(),
+	}
+}
+
+// SetProfile replaces the thresholds of the sorts with those of p. Zero
+// fields restore the built-in values, so SetProfile(Profile{}) undoes any
+// earlier profile.
+//
+// SetProfile is safe to call while other goroutines sort. Every threshold is
+// replaced atomically, but not all of them at once: a sort that runs
+// concurrently may see a mix of old and new values, which affects only its
+// speed.
+func SetProfile(p Profile) error {
+	fields := []struct {
+		name            string
+		value, fallback int
+		target          *threshold
+	}{
+		{"SmallSortMax8", p.SmallSortMax8, builtinProfile.SmallSortMax8, smallSortMax[1]},
+		{"SmallSortMax16", p.SmallSortMax16, builtinProfile.SmallSortMax16, smallSortMax[2]},
+		{"SmallSortMax32", p.SmallSortMax32, builtinProfile.SmallSortMax32, smallSortMax[4]},
+		{"SmallSortMax64", p.SmallSortMax64, builtinProfile.SmallSortMax64, smallSortMax[8]},
+		{"WideDigitMinLen", p.WideDigitMinLen, builtinProfile.WideDigitMinLen, wideDigitMinLen},
+		{"CombinedScatterMinLen", p.CombinedScatterMinLen, builtinProfile.CombinedScatterMinLen, combinedScatterMinLen},
+		{"HybridMinLen", p.HybridMinLen, builtinProfile.HybridMinLen, hybridMinLen},
+		{"ParallelThreshold", p.ParallelThreshold, builtinProfile.ParallelThreshold, parallelThreshold},
+	}
+
+	for _, f := range fields {
+		if f.value < 0 {
+			return fmt.Errorf("%w: %s is %d", ErrInvalidProfile, f.name, f.value)
+		}
+	}
+	for _, f := range fields {
+		if f.value > 0 {
+			f.target.set(f.value)
+		} else {
+			f.target.set(f.fallback)
+		}
+	}
+	return nil
+}
+
+// ReadProfile reads a profile written by [Profile.WriteFile].
+func ReadProfile(name string) (Profile, error) {
+	var p Profile
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return p, err
+	}
+	if err := json.Unmarshal(content, &p); err != nil {
+		return p, fmt.Errorf("tuning profile %s: %w", name, err)
+	}
+	return p, nil
+}
+
+// WriteFile writes p to the named file as indented JSON, creating or
+// truncating it.
+func (p Profile) WriteFile(name string) error {
+	content, err := json.MarshalIndent(p, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(name, append(content, '\n'), 0o644)
+}
+
+// LoadProfile reads the named profile and applies it with [SetProfile].
+//
+// Example:
+//
+//	if err := radixsort.LoadProfile("/etc/myapp/radixsort.json"); err != nil {
+//	    log.Printf("using the built-in thresholds: %v", err)
+//	}
+func LoadProfile(name string) error {
+	p, err := ReadProfile(name)
+	if err != nil {
+		return err
+	}
+	return SetProfile(p)
+}
+
+// LoadEnvProfile loads the profile named by the environment variable
+// [ProfileEnv], if it is set and not empty.
+//
+// The package calls LoadEnvProfile during initialization and keeps the
+// built-in thresholds if it fails. Programs that want to report the failure
+// call it again.
+func LoadEnvProfile() error {
+	name := os.Getenv(ProfileEnv)
+	if name == "" {
+		return nil
+	}
+	return LoadProfile(name)
+}
+
+func init() {
+	_ = LoadEnvProfile()
+}
@@ -0,0 +1,172 @@
+package radixsort_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+func TestSetProfile(t *testing.T) {
+	builtin := radixsort.CurrentProfile()
+	defer radixsort.SetProfile(radixsort.Profile{})
+
+	// Thresholds far from the built-in ones route short inputs through the
+	// kernels meant for long ones.
+	tuned := radixsort.Profile{
+		SmallSortMax64:        300,
+		WideDigitMinLen:       1 << 12,
+		CombinedScatterMinLen: 1 << 12,
+		HybridMinLen:          1 << 16,
+		ParallelThreshold:     1000,
+	}
+	if err := radixsort.SetProfile(tuned); err != nil {
+		t.Fatalf("SetProfile failed: %v", err)
+	}
+	current := radixsort.CurrentProfile()
+	want := builtin
+	want.SmallSortMax64, want.WideDigitMinLen = tuned.SmallSortMax64, tuned.WideDigitMinLen
+	want.CombinedScatterMinLen, want.HybridMinLen = tuned.CombinedScatterMinLen, tuned.HybridMinLen
+	want.ParallelThreshold = tuned.ParallelThreshold
+	if current != want {
+		t.Errorf("CurrentProfile() = %+v, want %+v", current, want)
+	}
+
+	for _, size := range []int{250, 5000, 200_000} {
+		data := randomUint64(size)
+		want := slices.Clone(data)
+		slices.Sort(want)
+		if err := radixsort.Uint64(data, make([]uint64, size)); err != nil {
+			t.Fatalf("Uint64 failed: %v", err)
+		}
+		if !slices.Equal(want, data) {
+			t.Errorf("Uint64 failed to sort %d elements with the tuned profile", size)
+		}
+
+		data = randomUint64(size)
+		p := radixsort.Parallel{Workers: 3}
+		if err := p.Uint64(data, make([]uint64, size)); err != nil {
+			t.Fatalf("Parallel.Uint64 failed: %v", err)
+		}
+		if !slices.IsSorted(data) {
+			t.Errorf("Parallel.Uint64 failed to sort %d elements with the tuned profile", size)
+		}
+
+		data32 := make([]uint32, size)
+		for i, v := range randomUint64(size) {
+			data32[i] = uint32(v)
+		}
+		if err := radixsort.Uint32(data32, make([]uint32, size)); err != nil {
+			t.Fatalf("Uint32 failed: %v", err)
+		}
+		if !slices.IsSorted(data32) {
+			t.Errorf("Uint32 failed to sort %d elements with the tuned profile", size)
+		}
+	}
+
+	err := radixsort.SetProfile(radixsort.Profile{SmallSortMax8: 10, HybridMinLen: -1})
+	if !errors.Is(err, radixsort.ErrInvalidProfile) {
+		t.Errorf("error = %v, want %v", err, radixsort.ErrInvalidProfile)
+	}
+	if got := radixsort.CurrentProfile(); got != want {
+		t.Errorf("a rejected profile changed the thresholds to %+v", got)
+	}
+
+	if err := radixsort.SetProfile(radixsort.Profile{}); err != nil {
+		t.Fatalf("SetProfile failed: %v", err)
+	}
+	if got := radixsort.CurrentProfile(); got != builtin {
+		t.Errorf("zero profile: CurrentProfile() = %+v, want %+v", got, builtin)
+	}
+}
+
+func TestProfileFile(t *testing.T) {
+	defer radixsort.SetProfile(radixsort.Profile{})
+	dir := t.TempDir()
+
+	name := filepath.Join(dir, "profile.json")
+	saved := radixsort.Profile{Machine: "test", SmallSortMax32: 100, HybridMinLen: 1 << 20}
+	if err := saved.WriteFile(name); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	loaded, err := radixsort.ReadProfile(name)
+	if err != nil {
+		t.Fatalf("ReadProfile failed: %v", err)
+	}
+	if loaded != saved {
+		t.Errorf("ReadProfile() = %+v, want %+v", loaded, saved)
+	}
+
+	// Unknown fields, e.g. from a newer version, are ignored.
+	partial := filepath.Join(dir, "partial.json")
+	if err := os.WriteFile(partial, []byte(`{"small_sort_max_64": 64, "future": 1}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(radixsort.ProfileEnv, partial)
+	if err := radixsort.LoadEnvProfile(); err != nil {
+		t.Fatalf("LoadEnvProfile failed: %v", err)
+	}
+	if got := radixsort.CurrentProfile().SmallSortMax64; got != 64 {
+		t.Errorf("SmallSortMax64 = %d, want 64", got)
+	}
+
+	malformed := filepath.Join(dir, "malformed.json")
+	if err := os.WriteFile(malformed, []byte(`{"small_sort_max_64": "many"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{malformed, filepath.Join(dir, "missing.json")} {
+		if err := radixsort.LoadProfile(name); err == nil {
+			t.Errorf("LoadProfile(%q) succeeded, want an error", name)
+		}
+	}
+
+	t.Setenv(radixsort.ProfileEnv, "")
+	if err := radixsort.LoadEnvProfile(); err != nil {
+		t.Errorf("LoadEnvProfile without %s = %v, want nil", radixsort.ProfileEnv, err)
+	}
+}
+
+func TestSetProfileWhileSorting(t *testing.T) {
+	defer radixsort.SetProfile(radixsort.Profile{})
+
+	// Under the race detector, the profile swaps below would be reported as
+	// races if the sorts read the thresholds without synchronization.
+	done := make(chan struct{})
+	swapped := make(chan struct{})
+	go func() {
+		defer close(swapped)
+		tuned := radixsort.Profile{SmallSortMax64: 300, WideDigitMinLen: 1 << 12, HybridMinLen: 1 << 16}
+		for i := 0; ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			p := radixsort.Profile{}
+			if i%2 == 0 {
+				p = tuned
+			}
+			if err := radixsort.SetProfile(p); err != nil {
+				t.Errorf("SetProfile failed: %v", err)
+				return
+			}
+		}
+	}()
+
+	for range 20 {
+		data := randomUint64(100_000)
+		want := slices.Clone(data)
+		slices.Sort(want)
+		if err := radixsort.Uint64(data, make([]uint64, len(data))); err != nil {
+			t.Fatalf("Uint64 failed: %v", err)
+		}
+		if !slices.Equal(want, data) {
+			t.Errorf("Uint64 failed to sort data correctly while the profile changed")
+		}
+	}
+	close(done)
+	<-swapped
+}
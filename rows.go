@@ -0,0 +1,147 @@
+package radixsort
+
+import (
+	"unsafe"
+
+	"github.com/sagernet/sing/common/x/constraints"
+)
+
+// RowsUint32 sorts rows lexicographically, treating each row as a multi-word
+// key whose first column is the most significant.
+//
+// All rows must have the same length. Only the row slices are reordered;
+// their contents are not copied. The buf slice is used for temporary storage
+// and must have len(buf) >= len(rows).
+//
+// The rows are sorted with LSD passes over the columns, from the last column
+// to the first. Each pass sorts a compact (key, row index) array, two 32-bit
+// columns at a time, and skips every byte that is identical across all rows,
+// so constant columns cost a single counting pass. The rows are moved once,
+// after the permutation is known. The sort is stable, which makes it suitable
+// for deduplication of feature vectors.
+//
+// Returns ErrInvalidBufferSize if len(buf) < len(rows) and
+// ErrInvalidRowLength if the rows have different lengths.
+//
+// Example:
+//
+//	rows := [][]uint32{{2, 1}, {1, 9}, {2, 0}}
+//	buf := make([][]uint32, len(rows))
+//	err := RowsUint32(rows, buf)
+//	// rows is now: [[1 9] [2 0] [2 1]]
+func RowsUint32(rows, buf [][]uint32) error {
+	return sortRows(rows, buf)
+}
+
+// RowsUint64 sorts rows lexicographically, treating each row as a multi-word
+// key whose first column is the most significant.
+//
+// See [RowsUint32] for details.
+func RowsUint64(rows, buf [][]uint64) error {
+	return sortRows(rows, buf)
+}
+
+// StridedUint32 sorts the rows of a flat matrix lexicographically.
+//
+// Row i is data[i*stride : (i+1)*stride], and len(data) must be a multiple of
+// stride. The rows are moved as a whole, once, after the sorted order has been
+// computed as described in [RowsUint32]. The buf slice is used for temporary
+// storage and must have len(buf) >= len(data).
+//
+// Returns ErrInvalidBufferSize if len(buf) < len(data) and
+// ErrInvalidRowLength if stride is not positive or does not divide len(data).
+//
+// Example:
+//
+//	data := []uint32{2, 1, 1, 9, 2, 0}
+//	buf := make([]uint32, len(data))
+//	err := StridedUint32(data, buf, 2)
+//	// data is now: [1 9 2 0 2 1]
+func StridedUint32(data, buf []uint32, stride int) error {
+	return sortStrided(data, buf, stride)
+}
+
+// StridedUint64 sorts the rows of a flat matrix lexicographically.
+//
+// See [StridedUint32] for details.
+func StridedUint64(data, buf []uint64, stride int) error {
+	return sortStrided(data, buf, stride)
+}
+
+func sortRows[T uint32 | uint64](rows, buf [][]T) error {
+	if len(buf) < len(rows) {
+		return ErrInvalidBufferSize
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	width := len(rows[0])
+	for _, row := range rows {
+		if len(row) != width {
+			return ErrInvalidRowLength
+		}
+	}
+
+	if len(rows) < 2 || width == 0 {
+		return nil
+	}
+
+	perm := rowPermutation(len(rows), width, func(i, c int) T { return rows[i][c] })
+	permuteColumn(rows, buf, perm)
+
+	return nil
+}
+
+func sortStrided[T uint32 | uint64](data, buf []T, stride int) error {
+	if stride <= 0 || len(data)%stride != 0 {
+		return ErrInvalidRowLength
+	}
+	if len(buf) < len(data) {
+		return ErrInvalidBufferSize
+	}
+
+	n := len(data) / stride
+	if n < 2 {
+		return nil
+	}
+
+	perm := rowPermutation(n, stride, func(i, c int) T { return data[i*stride+c] })
+	for i, p := range perm {
+		copy(buf[i*stride:(i+1)*stride], data[p.idx*stride:(p.idx+1)*stride])
+	}
+	copy(data, buf[:len(data)])
+
+	return nil
+}
+
+// rowPermutation returns the stable permutation that sorts n rows of width
+// columns lexicographically; cell returns the value of column c in row i.
+func rowPermutation[T constraints.Unsigned](n, width int, cell func(i, c int) T) []keyIndex {
+	perm := make([]keyIndex, 2*n)
+	perm, permBuf := perm[:n], perm[n:]
+	for i := range perm {
+		perm[i].idx = i
+	}
+
+	// 32-bit columns are sorted in pairs packed into one 64-bit key.
+	step := 1
+	if unsafe.Sizeof(T(0)) == 4 {
+		step = 2
+	}
+
+	for c := width - 1; c >= 0; c -= step {
+		if step == 2 && c > 0 {
+			for i := range perm {
+				perm[i].key = uint64(cell(perm[i].idx, c-1))<<32 | uint64(cell(perm[i].idx, c))
+			}
+		} else {
+			for i := range perm {
+				perm[i].key = uint64(cell(perm[i].idx, c))
+			}
+		}
+		radixKeyIndex(perm, permBuf)
+	}
+
+	return perm
+}
@@ -0,0 +1,136 @@
+package radixsort_test
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRowsUint32(t *testing.T) {
+	testRowsSort(t, radixsort.RowsUint32, radixsort.StridedUint32, "Uint32")
+}
+
+func TestRowsUint64(t *testing.T) {
+	testRowsSort(t, radixsort.RowsUint64, radixsort.StridedUint64, "Uint64")
+}
+
+func testRowsSort[T uint32 | uint64](t *testing.T, rowsFunc func(rows, buf [][]T) error, stridedFunc func(data, buf []T, stride int) error, typeName string) {
+	tests := []struct {
+		name string
+		in   [][]T
+		want [][]T
+	}{
+		{
+			name: "empty",
+			in:   [][]T{},
+			want: [][]T{},
+		},
+		{
+			name: "single row",
+			in:   [][]T{{3, 2, 1}},
+			want: [][]T{{3, 2, 1}},
+		},
+		{
+			name: "single column",
+			in:   [][]T{{3}, {1}, {2}},
+			want: [][]T{{1}, {2}, {3}},
+		},
+		{
+			name: "even width",
+			in:   [][]T{{2, 1}, {1, 9}, {2, 0}, {1, 9}},
+			want: [][]T{{1, 9}, {1, 9}, {2, 0}, {2, 1}},
+		},
+		{
+			name: "odd width",
+			in:   [][]T{{5, 0, 1}, {5, 0, 0}, {0, 7, 7}, {5, 1, 0}},
+			want: [][]T{{0, 7, 7}, {5, 0, 0}, {5, 0, 1}, {5, 1, 0}},
+		},
+		{
+			name: "large values",
+			in:   [][]T{{^T(0), 0}, {1 << 31, ^T(0)}, {1 << 31, 1}},
+			want: [][]T{{1 << 31, 1}, {1 << 31, ^T(0)}, {^T(0), 0}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := make([][]T, len(tt.in))
+			var flat []T
+			for i, row := range tt.in {
+				rows[i] = append([]T{}, row...)
+				flat = append(flat, row...)
+			}
+
+			if err := rowsFunc(rows, make([][]T, len(rows))); err != nil {
+				t.Fatalf("Rows%s failed: %v", typeName, err)
+			}
+			if !cmp.Equal(tt.want, rows) {
+				t.Errorf("case: %s; Rows%s(%v) = %v, want %v", tt.name, typeName, tt.in, rows, tt.want)
+			}
+
+			if len(tt.in) == 0 {
+				return
+			}
+			if err := stridedFunc(flat, make([]T, len(flat)), len(tt.in[0])); err != nil {
+				t.Fatalf("Strided%s failed: %v", typeName, err)
+			}
+			if !cmp.Equal(slices.Concat(tt.want...), flat) {
+				t.Errorf("case: %s; Strided%s(%v) = %v, want %v", tt.name, typeName, tt.in, flat, slices.Concat(tt.want...))
+			}
+		})
+	}
+
+	t.Run("random", func(t *testing.T) {
+		const n, width = 20_000, 5
+
+		rows := make([][]T, n)
+		for i := range rows {
+			rows[i] = make([]T, width)
+			for c := range rows[i] {
+				rows[i][c] = T(rand.Intn(4))
+			}
+			rows[i][width-1] = T(rand.Uint64())
+		}
+		want := slices.Clone(rows)
+		slices.SortStableFunc(want, slices.Compare)
+		flat := slices.Concat(rows...)
+
+		if err := rowsFunc(rows, make([][]T, n)); err != nil {
+			t.Fatalf("Rows%s failed: %v", typeName, err)
+		}
+		if !cmp.Equal(want, rows) {
+			t.Errorf("Rows%s failed to sort random rows", typeName)
+		}
+
+		if err := stridedFunc(flat, make([]T, len(flat)), width); err != nil {
+			t.Fatalf("Strided%s failed: %v", typeName, err)
+		}
+		if !slices.Equal(slices.Concat(want...), flat) {
+			t.Errorf("Strided%s failed to sort random rows", typeName)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		rows := [][]T{{1, 2}, {3}}
+		if err := rowsFunc(rows, make([][]T, 2)); !errors.Is(err, radixsort.ErrInvalidRowLength) {
+			t.Errorf("Rows%s: error = %v, want %v", typeName, err, radixsort.ErrInvalidRowLength)
+		}
+		if err := rowsFunc([][]T{{1}, {0}}, make([][]T, 1)); !errors.Is(err, radixsort.ErrInvalidBufferSize) {
+			t.Errorf("Rows%s: error = %v, want %v", typeName, err, radixsort.ErrInvalidBufferSize)
+		}
+
+		flat := []T{1, 2, 3}
+		for _, stride := range []int{0, -1, 2} {
+			if err := stridedFunc(flat, make([]T, 3), stride); !errors.Is(err, radixsort.ErrInvalidRowLength) {
+				t.Errorf("Strided%s(stride=%d): error = %v, want %v", typeName, stride, err, radixsort.ErrInvalidRowLength)
+			}
+		}
+		if err := stridedFunc([]T{2, 1}, make([]T, 1), 1); !errors.Is(err, radixsort.ErrInvalidBufferSize) {
+			t.Errorf("Strided%s: error = %v, want %v", typeName, err, radixsort.ErrInvalidBufferSize)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package radixsort
+
+// combinedScatterMinLen is the shortest input of 64-bit keys whose scatter
+// passes stage the elements in write-combining buffers.
+//
+// A plain scatter pass writes to 256 locations spread over the whole
+// destination. Once the arrays no longer fit into L2 (4 MiB of uint64 at this
+// length), nearly every store misses the cache and often the TLB. Below it the
+// staging costs more than it saves (see BenchmarkCombinedScatter).
+var combinedScatterMinLen = newThreshold(1 << 19)
+
+// combinedLineLen is the number of elements staged per bucket before they are
+// written out together. Four cache lines per bucket measured best; the
+// staging area of 256 buckets then takes 64 KiB.
+const combinedLineLen = 32
+
+// scatterCombined distributes src into dst by the byte of v-base at shift,
+// like the scatter loop of radix64b8Base, but stages the elements of every
+// bucket in a small buffer and flushes full buffers as one block. The order
+// within each bucket is preserved, so the pass stays stable.
+//
+// offsets holds the start offset of every bucket in dst and is advanced past
+// the elements written.
+func scatterCombined(src, dst []uint64, offsets *[256]uint, shift uint, base uint64) {
+	var stage [256][combinedLineLen]uint64
+	var fill [256]uint
+
+	for _, v := range src {
+		b := uint8((v - base) >> shift)
+		f := fill[b]
+		stage[b][f%combinedLineLen] = v
+		if f%combinedLineLen == combinedLineLen-1 {
+			o := offsets[b]
+			*(*[combinedLineLen]uint64)(dst[o : o+combinedLineLen]) = stage[b]
+			offsets[b] = o + combinedLineLen
+		}
+		fill[b] = f + 1
+	}
+
+	for b := range 256 {
+		o := offsets[b]
+		f := fill[b] % combinedLineLen
+		copy(dst[o:o+f], stage[b][:f])
+		offsets[b] = o + f
+	}
+}
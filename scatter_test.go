@@ -0,0 +1,45 @@
+package radixsort_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+func TestCombinedScatter(t *testing.T) {
+	// Long enough for the write-combined scatter, and not a multiple of the
+	// staging size, so that partially filled buckets are flushed at the end.
+	const size = 1<<19 + 7
+
+	inputs := map[string]func() uint64{
+		"uniform": rand.Uint64,
+		// Most elements fall into a few buckets of every digit.
+		"skewed": func() uint64 { return uint64(rand.Intn(4)) * 0x0101_0101_0101_0101 },
+		"mixed": func() uint64 {
+			if rand.Intn(8) == 0 {
+				return rand.Uint64()
+			}
+			return uint64(rand.Intn(300))
+		},
+	}
+
+	for name, gen := range inputs {
+		t.Run(name, func(t *testing.T) {
+			data := make([]uint64, size)
+			for i := range data {
+				data[i] = gen()
+			}
+			want := slices.Clone(data)
+			slices.Sort(want)
+
+			if err := radixsort.Uint64(data, make([]uint64, size)); err != nil {
+				t.Fatalf("Uint64 failed: %v", err)
+			}
+			if !slices.Equal(want, data) {
+				t.Errorf("Uint64 failed to sort %s input", name)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package radixsort
+
+import "github.com/sagernet/sing/common/x/constraints"
+
+// shortInsertionMax is the longest segment or batch slice that is sorted
+// with insertion sort; longer ones are radix sorted.
+const shortInsertionMax = 48
+
+// SegmentedUint32 sorts every segment of data independently in ascending order.
+//
+// Segment k is data[segments[k]:segments[k+1]], so segments holds the segment
+// boundaries as in the offsets array of a CSR (compressed sparse row) matrix:
+// it must be non-decreasing and every entry must be within [0, len(data)].
+// Elements outside of the described segments are left untouched.
+//
+// Short segments are sorted with insertion sort. Longer segments are radix
+// sorted with passes over only the bytes that differ within the segment,
+// sharing one offset table across all segments, so many tiny segments do not
+// pay for a full table setup each.
+//
+// The buf slice is used for temporary storage and must be at least as long
+// as the longest segment.
+//
+// Returns ErrInvalidSegments if segments is malformed and
+// ErrInvalidBufferSize if buf is shorter than the longest segment.
+//
+// Example:
+//
+//	// Neighbour lists of vertices 0, 1 and 2.
+//	data := []uint32{7, 3, 5, 9, 2, 8, 1}
+//	segments := []int{0, 3, 3, 7}
+//	buf := make([]uint32, 4)
+//	err := SegmentedUint32(data, buf, segments)
+//	// data is now: [3, 5, 7, 1, 2, 8, 9]
+func SegmentedUint32(data, buf []uint32, segments []int) error {
+	return segmented(data, buf, segments)
+}
+
+// SegmentedUint64 sorts every segment of data independently in ascending order.
+//
+// See [SegmentedUint32] for the description of segments and buf.
+func SegmentedUint64(data, buf []uint64, segments []int) error {
+	return segmented(data, buf, segments)
+}
+
+func segmented[T constraints.Unsigned](data, buf []T, segments []int) error {
+	longest := 0
+	for k := 1; k < len(segments); k++ {
+		if segments[k-1] < 0 || segments[k] < segments[k-1] || segments[k] > len(data) {
+			return ErrInvalidSegments
+		}
+		longest = max(longest, segments[k]-segments[k-1])
+	}
+	if len(segments) == 1 && (segments[0] < 0 || segments[0] > len(data)) {
+		return ErrInvalidSegments
+	}
+
+	if len(buf) < longest {
+		return ErrInvalidBufferSize
+	}
+
+	var offsets [8][256]uint
+	for k := 1; k < len(segments); k++ {
+		seg := data[segments[k-1]:segments[k]]
+		if len(seg) <= shortInsertionMax {
+			insertionSort(seg)
+			continue
+		}
+		digits, n := varyingDigits(seg)
+		radixSegment(seg, buf, digits[:n], &offsets)
+	}
+
+	return nil
+}
+
+// varyingDigits returns the indexes of the bytes that are not identical
+// across all elements of data, least significant first, and their count.
+func varyingDigits[T constraints.Unsigned](data []T) ([8]uint, int) {
+	// Bits set in diff differ between at least two elements.
+	or, and := T(0), ^T(0)
+	for _, v := range data {
+		or |= v
+		and &= v
+	}
+	diff := or ^ and
+
+	var digits [8]uint
+	n := 0
+	for d := range uint(len(digits)) {
+		if uint8(diff>>(d*8)) != 0 {
+			digits[n] = d
+			n++
+		}
+	}
+	return digits, n
+}
+
+// radixSegment performs an LSD radix sort with 8-bit buckets over only the
+// given digits, which must include every byte that is not identical across
+// all elements of data (see varyingDigits).
+//
+// offsets is scratch space shared between calls; only the digits that are
+// actually sorted are cleared, which makes the sort cheap for short inputs.
+// The buffer length must be at least as large as data.
+func radixSegment[T constraints.Unsigned](data, buf []T, digits []uint, offsets *[8][256]uint) {
+	if len(digits) == 0 {
+		return
+	}
+
+	for _, d := range digits {
+		clear(offsets[d][:])
+	}
+	for _, v := range data {
+		for _, d := range digits {
+			offsets[d][uint8(v>>(d*8))]++
+		}
+	}
+
+	for _, d := range digits {
+		acc := uint(0)
+		for b := range 256 {
+			offsets[d][b], acc = acc, acc+offsets[d][b]
+		}
+	}
+
+	src, dst := data, buf[:len(data)]
+	for _, d := range digits {
+		for _, v := range src {
+			index := offsets[d][uint8(v>>(d*8))]
+			dst[index] = v
+			offsets[d][uint8(v>>(d*8))]++
+		}
+		src, dst = dst, src
+	}
+
+	if len(digits)&1 == 1 {
+		copy(data, src)
+	}
+}
@@ -0,0 +1,140 @@
+package radixsort_test
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/exp/constraints"
+)
+
+func TestSegmentedUint32(t *testing.T) {
+	testSegmentedSort(t, radixsort.SegmentedUint32, "SegmentedUint32")
+}
+
+func TestSegmentedUint64(t *testing.T) {
+	testSegmentedSort(t, radixsort.SegmentedUint64, "SegmentedUint64")
+}
+
+func TestSegmentedUint32Random(t *testing.T) {
+	testSegmentedSortRandom(t, radixsort.SegmentedUint32, "SegmentedUint32")
+}
+
+func TestSegmentedUint64Random(t *testing.T) {
+	testSegmentedSortRandom(t, radixsort.SegmentedUint64, "SegmentedUint64")
+}
+
+func testSegmentedSort[T constraints.Unsigned](t *testing.T, sortFunc func(data, buf []T, segments []int) error, sortFuncName string) {
+	tests := []struct {
+		name     string
+		in       []T
+		segments []int
+		bufSize  int
+		want     []T
+		wantErr  error
+	}{
+		{
+			name:     "no segments",
+			in:       []T{3, 2, 1},
+			segments: nil,
+			want:     []T{3, 2, 1},
+		},
+		{
+			name:     "single segment",
+			in:       []T{3, 2, 1},
+			segments: []int{0, 3},
+			bufSize:  3,
+			want:     []T{1, 2, 3},
+		},
+		{
+			name:     "csr neighbour lists",
+			in:       []T{7, 3, 5, 9, 2, 8, 1},
+			segments: []int{0, 3, 3, 7},
+			bufSize:  4,
+			want:     []T{3, 5, 7, 1, 2, 8, 9},
+		},
+		{
+			name:     "untouched tail and head",
+			in:       []T{9, 8, 3, 1, 2, 0},
+			segments: []int{2, 5},
+			bufSize:  3,
+			want:     []T{9, 8, 1, 2, 3, 0},
+		},
+		{
+			name:     "decreasing boundaries",
+			in:       []T{3, 2, 1},
+			segments: []int{0, 2, 1},
+			bufSize:  3,
+			want:     []T{3, 2, 1},
+			wantErr:  radixsort.ErrInvalidSegments,
+		},
+		{
+			name:     "boundary out of range",
+			in:       []T{3, 2, 1},
+			segments: []int{0, 4},
+			bufSize:  4,
+			want:     []T{3, 2, 1},
+			wantErr:  radixsort.ErrInvalidSegments,
+		},
+		{
+			name:     "buffer shorter than longest segment",
+			in:       []T{3, 2, 1, 0},
+			segments: []int{0, 1, 4},
+			bufSize:  2,
+			want:     []T{3, 2, 1, 0},
+			wantErr:  radixsort.ErrInvalidBufferSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := append([]T{}, tt.in...)
+			buf := make([]T, tt.bufSize)
+
+			err := sortFunc(data, buf, tt.segments)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("%s: error = %v, want %v", sortFuncName, err, tt.wantErr)
+			}
+
+			if !cmp.Equal(tt.want, data) {
+				t.Errorf("case: %s; %s(%v, %v) = %v, want %v", tt.name, sortFuncName, tt.in, tt.segments, data, tt.want)
+			}
+		})
+	}
+}
+
+func testSegmentedSortRandom[T constraints.Unsigned](t *testing.T, sortFunc func(data, buf []T, segments []int) error, sortFuncName string) {
+	const size = 1_000_000
+
+	data := make([]T, size)
+	for i := range data {
+		data[i] = T(rand.Uint64() >> rand.Intn(64))
+	}
+
+	// Mostly short segments with a few long ones, like vertex degrees in a graph.
+	segments := []int{0}
+	for segments[len(segments)-1] < size {
+		length := rand.Intn(20)
+		if rand.Intn(100) == 0 {
+			length = rand.Intn(20_000)
+		}
+		segments = append(segments, min(segments[len(segments)-1]+length, size))
+	}
+
+	want := append([]T{}, data...)
+	for k := 1; k < len(segments); k++ {
+		slices.Sort(want[segments[k-1]:segments[k]])
+	}
+
+	buf := make([]T, 20_000)
+	if err := sortFunc(data, buf, segments); err != nil {
+		t.Fatalf("%s failed: %v", sortFuncName, err)
+	}
+
+	if !slices.Equal(want, data) {
+		t.Errorf("%s failed to sort segments correctly", sortFuncName)
+	}
+}
@@ -0,0 +1,119 @@
+// Package slices provides radix sorts with the calling conventions of the
+// standard library package slices.
+//
+// The functions allocate their scratch memory internally, taken from the
+// pooled buffers of [radixsort.DefaultSorter], and cannot fail. Code that
+// sorts numbers with the standard library can switch by changing the import:
+//
+//	import "github.com/Kaidzen-62/radixsort/slices"
+//
+//	slices.Sort(ids)
+//
+// Every function orders values like [radixsort.OrderedKey]: signed integers
+// as usual, and floats as -Inf < negative values < -0 < +0 < positive
+// values < +Inf, with NaNs first or last depending on their sign bit. Unlike
+// the standard library, -0 and +0 are distinct, and NaNs have a fixed place,
+// so [IsSorted] and [BinarySearch] agree with [Sort] on every input.
+//
+// Callers that want to avoid the pool, reuse their own buffers or handle
+// buffer errors use the functions of package radixsort directly.
+package slices
+
+import (
+	"sort"
+	"unsafe"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+// Sort sorts a slice of numbers in ascending order.
+//
+// Example:
+//
+//	s := []int{5, 2, 9, 1}
+//	slices.Sort(s)
+//	// s is now [1, 2, 5, 9]
+func Sort[S ~[]E, E radixsort.ConstraintNumbers](x S) {
+	if len(x) < 2 {
+		return
+	}
+
+	var zero E
+	one := zero + 1
+	isFloat := one/2 != 0
+	isSigned := !isFloat && zero-one < zero
+
+	s := radixsort.DefaultSorter
+	p := unsafe.Pointer(unsafe.SliceData(x))
+	switch size := unsafe.Sizeof(zero); {
+	case isFloat && size == 8:
+		s.Float64(unsafe.Slice((*float64)(p), len(x)))
+	case isFloat:
+		s.Float32(unsafe.Slice((*float32)(p), len(x)))
+	case isSigned && size == 8:
+		s.Int64(unsafe.Slice((*int64)(p), len(x)))
+	case isSigned && size == 4:
+		s.Int32(unsafe.Slice((*int32)(p), len(x)))
+	case isSigned && size == 2:
+		s.Int16(unsafe.Slice((*int16)(p), len(x)))
+	case isSigned:
+		s.Int8(unsafe.Slice((*int8)(p), len(x)))
+	case size == 8:
+		s.Uint64(unsafe.Slice((*uint64)(p), len(x)))
+	case size == 4:
+		s.Uint32(unsafe.Slice((*uint32)(p), len(x)))
+	case size == 2:
+		s.Uint16(unsafe.Slice((*uint16)(p), len(x)))
+	default:
+		s.Uint8(unsafe.Slice((*uint8)(p), len(x)))
+	}
+}
+
+// SortStableKey sorts a slice in ascending order of the numeric key of its
+// elements, keeping equal elements in their original order. It is the radix
+// counterpart of slices.SortStableFunc for comparisons of a single key.
+//
+// Example:
+//
+//	slices.SortStableKey(users, func(u User) int64 { return u.CreatedAt })
+func SortStableKey[S ~[]E, E any, K radixsort.ConstraintNumbers](x S, key func(a E) K) {
+	radixsort.GenericPooled(radixsort.DefaultSorter, []E(x), key)
+}
+
+// IsSorted reports whether x is sorted in ascending order.
+func IsSorted[S ~[]E, E radixsort.ConstraintNumbers](x S) bool {
+	for i := 1; i < len(x); i++ {
+		if radixsort.OrderedKey(x[i]) < radixsort.OrderedKey(x[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSortedKey reports whether x is sorted in ascending order of key.
+func IsSortedKey[S ~[]E, E any, K radixsort.ConstraintNumbers](x S, key func(a E) K) bool {
+	for i := 1; i < len(x); i++ {
+		if radixsort.OrderedKey(key(x[i])) < radixsort.OrderedKey(key(x[i-1])) {
+			return false
+		}
+	}
+	return true
+}
+
+// BinarySearch searches for target in a sorted slice and returns the earliest
+// position where target is found, or the position where target would appear
+// in the sort order; it also returns a bool saying whether the target is
+// really found in the slice. The slice must be sorted in increasing order.
+func BinarySearch[S ~[]E, E radixsort.ConstraintNumbers](x S, target E) (int, bool) {
+	t := radixsort.OrderedKey(target)
+	i := sort.Search(len(x), func(i int) bool { return radixsort.OrderedKey(x[i]) >= t })
+	return i, i < len(x) && radixsort.OrderedKey(x[i]) == t
+}
+
+// BinarySearchKey works like [BinarySearch] on a slice sorted by key, such as
+// by [SortStableKey], and searches for the elements whose key is target.
+func BinarySearchKey[S ~[]E, E any, K radixsort.ConstraintNumbers](x S, target K, key func(a E) K) (int, bool) {
+	t := radixsort.OrderedKey(target)
+	i := sort.Search(len(x), func(i int) bool { return radixsort.OrderedKey(key(x[i])) >= t })
+	return i, i < len(x) && radixsort.OrderedKey(key(x[i])) == t
+}
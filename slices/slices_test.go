@@ -0,0 +1,137 @@
+package slices_test
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	stdslices "slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"github.com/Kaidzen-62/radixsort/slices"
+	"golang.org/x/exp/constraints"
+)
+
+type celsius float32
+
+type id uint16
+
+func testSort[E constraints.Integer | constraints.Float](t *testing.T, name string, gen func() E) {
+	for _, size := range []int{0, 1, 7, 200, 5000} {
+		x := make([]E, size)
+		for i := range x {
+			x[i] = gen()
+		}
+		want := stdslices.Clone(x)
+		stdslices.SortFunc(want, func(a, b E) int {
+			return cmp.Compare(radixsort.OrderedKey(a), radixsort.OrderedKey(b))
+		})
+
+		slices.Sort(x)
+		if !bitsEqual(want, x) {
+			t.Errorf("Sort of %d %s values failed", size, name)
+		}
+		if !slices.IsSorted(x) {
+			t.Errorf("IsSorted of %d sorted %s values = false", size, name)
+		}
+	}
+}
+
+// bitsEqual compares by keys, which tells -0 from +0 and matches NaNs.
+func bitsEqual[E constraints.Integer | constraints.Float](a, b []E) bool {
+	return stdslices.EqualFunc(a, b, func(x, y E) bool {
+		return radixsort.OrderedKey(x) == radixsort.OrderedKey(y)
+	})
+}
+
+func TestSort(t *testing.T) {
+	testSort(t, "int", func() int { return rand.Int() - math.MaxInt/2 })
+	testSort(t, "int8", func() int8 { return int8(rand.Uint32()) })
+	testSort(t, "int16", func() int16 { return int16(rand.Uint32()) })
+	testSort(t, "int32", func() int32 { return int32(rand.Uint32()) })
+	testSort(t, "uint", func() uint { return uint(rand.Uint64()) })
+	testSort(t, "uint8", func() uint8 { return uint8(rand.Uint32()) })
+	testSort(t, "id", func() id { return id(rand.Uint32()) })
+	testSort(t, "uint32", rand.Uint32)
+	testSort(t, "uint64", rand.Uint64)
+	testSort(t, "celsius", func() celsius { return celsius(rand.NormFloat64() * 20) })
+	testSort(t, "float64", func() float64 {
+		switch rand.Intn(20) {
+		case 0:
+			return math.NaN()
+		case 1:
+			return math.Copysign(0, -1)
+		case 2:
+			return math.Inf(-1)
+		}
+		return rand.NormFloat64()
+	})
+}
+
+func TestIsSorted(t *testing.T) {
+	if slices.IsSorted([]float64{0, math.Copysign(0, -1)}) {
+		t.Error("IsSorted([0, -0]) = true, want false")
+	}
+	if !slices.IsSorted([]int8{-3, -1, 0, 5}) {
+		t.Error("IsSorted([-3, -1, 0, 5]) = false")
+	}
+	if slices.IsSorted([]uint{2, 1}) {
+		t.Error("IsSorted([2, 1]) = true")
+	}
+}
+
+func TestBinarySearch(t *testing.T) {
+	x := []int32{-10, -3, -3, 0, 4, 9}
+	tests := []struct {
+		target int32
+		pos    int
+		found  bool
+	}{
+		{-11, 0, false},
+		{-10, 0, true},
+		{-3, 1, true},
+		{1, 4, false},
+		{9, 5, true},
+		{10, 6, false},
+	}
+	for _, tt := range tests {
+		pos, found := slices.BinarySearch(x, tt.target)
+		if pos != tt.pos || found != tt.found {
+			t.Errorf("BinarySearch(%d) = %d, %t, want %d, %t", tt.target, pos, found, tt.pos, tt.found)
+		}
+	}
+
+	if pos, found := slices.BinarySearch([]float64{math.Inf(-1), -1, 2}, -1); pos != 1 || !found {
+		t.Errorf("BinarySearch(-1) = %d, %t, want 1, true", pos, found)
+	}
+}
+
+func TestKeyed(t *testing.T) {
+	type user struct {
+		name string
+		age  uint8
+	}
+	users := make([]user, 1000)
+	for i := range users {
+		users[i] = user{name: string(rune('a' + i%26)), age: uint8(rand.Intn(90))}
+	}
+	want := stdslices.Clone(users)
+	stdslices.SortStableFunc(want, func(a, b user) int { return cmp.Compare(a.age, b.age) })
+
+	age := func(u user) uint8 { return u.age }
+	slices.SortStableKey(users, age)
+	if !stdslices.Equal(want, users) {
+		t.Fatal("SortStableKey failed to sort stably")
+	}
+	if !slices.IsSortedKey(users, age) {
+		t.Error("IsSortedKey of sorted users = false")
+	}
+
+	pos, found := slices.BinarySearchKey(users, users[500].age, age)
+	if !found || users[pos].age != users[500].age || pos > 0 && users[pos-1].age == users[500].age {
+		t.Errorf("BinarySearchKey(%d) = %d, %t", users[500].age, pos, found)
+	}
+	if _, found := slices.BinarySearchKey(users, 200, age); found {
+		t.Error("BinarySearchKey found a missing age")
+	}
+}
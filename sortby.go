@@ -0,0 +1,219 @@
+package radixsort
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SortBy sorts a slice of structs by one or more of their fields using
+// reflection.
+//
+// data must be a slice of structs or a slice of pointers to structs. Each
+// entry of fields names a struct field, optionally followed by ",desc" to
+// sort that field in descending order. Earlier fields take precedence:
+// later fields only break ties between elements that are equal on all
+// preceding fields.
+//
+// When no fields are given, the sort keys are taken from `radix` struct tags.
+// The tag holds the key priority (1 is the most significant) and an optional
+// direction:
+//
+//	type Row struct {
+//	    Group int     `radix:"1"`
+//	    Score float64 `radix:"2,desc"`
+//	    Name  string
+//	}
+//
+// Supported field kinds are bool, all integer kinds and both float kinds.
+// The composite key is extracted once per element before sorting, so the cost
+// of reflection does not grow with the number of radix passes.
+//
+// The sort is stable. Unlike the other sorting functions, SortBy allocates
+// its own temporary storage.
+//
+// Returns an error wrapping ErrUnsupportedKind if a key field has a kind that
+// cannot be radix sorted, and a descriptive error if data is not a slice of
+// structs, a field does not exist, or an element or the embedded struct
+// pointer through which a promoted field is reached is nil.
+//
+// Example:
+//
+//	rows := []Row{{2, 0.5, "a"}, {1, 0.1, "b"}, {2, 0.9, "c"}}
+//	err := SortBy(rows, "Group", "Score,desc")
+//	// rows is now: [{1 0.1 b} {2 0.9 c} {2 0.5 a}]
+func SortBy(data any, fields ...string) error {
+	v := reflect.ValueOf(data)
+	if v.Kind() != reflect.Slice {
+		return fmt.Errorf("SortBy: data must be a slice of structs, got %T", data)
+	}
+
+	elemType := v.Type().Elem()
+	isPointer := elemType.Kind() == reflect.Pointer
+	if isPointer {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return fmt.Errorf("SortBy: data must be a slice of structs, got %T", data)
+	}
+
+	specs, err := sortBySpecs(elemType, fields)
+	if err != nil {
+		return err
+	}
+
+	n := v.Len()
+	if n < 2 {
+		return nil
+	}
+
+	// keys[f][i] holds the order-preserving key of field f for element i.
+	keys := make([][]uint64, len(specs))
+	for f := range keys {
+		keys[f] = make([]uint64, n)
+	}
+	for i := range n {
+		e := v.Index(i)
+		if isPointer {
+			if e.IsNil() {
+				return fmt.Errorf("SortBy: element %d is a nil pointer", i)
+			}
+			e = e.Elem()
+		}
+		for f, spec := range specs {
+			field, err := e.FieldByIndexErr(spec.index)
+			if err != nil {
+				return fmt.Errorf("SortBy: element %d: field %s: %w", i, spec.name, err)
+			}
+			keys[f][i] = spec.key(field)
+		}
+	}
+
+	// LSD over fields: sort by the least significant field first and rely on
+	// stability to keep that order among elements with equal preceding fields.
+	perm := make([]keyIndex, n)
+	for i := range perm {
+		perm[i].idx = i
+	}
+	permBuf := make([]keyIndex, n)
+	for f := len(specs) - 1; f >= 0; f-- {
+		for i := range perm {
+			perm[i].key = keys[f][perm[i].idx]
+		}
+		radixKeyIndex(perm, permBuf)
+	}
+
+	sorted := reflect.MakeSlice(v.Type(), n, n)
+	for i, p := range perm {
+		sorted.Index(i).Set(v.Index(p.idx))
+	}
+	reflect.Copy(v, sorted)
+
+	return nil
+}
+
+// sortBySpec describes a single struct field used as a sort key.
+type sortBySpec struct {
+	name     string
+	index    []int
+	kind     reflect.Kind
+	desc     bool
+	priority int
+}
+
+// key converts the field value into an unsigned key with the same ordering.
+func (s sortBySpec) key(v reflect.Value) uint64 {
+	var k uint64
+	switch s.kind {
+	case reflect.Bool:
+		if v.Bool() {
+			k = 1
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		k = uint64(v.Int()) ^ (1 << 63)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		k = v.Uint()
+	case reflect.Float32, reflect.Float64:
+		k = math.Float64bits(v.Float())
+		if k>>63 == 1 {
+			k = ^k
+		} else {
+			k ^= 1 << 63
+		}
+	}
+
+	if s.desc {
+		return ^k
+	}
+	return k
+}
+
+// sortBySpecs resolves the requested fields, or the `radix` tags of t when no
+// fields are given, into sort key specifications ordered by precedence.
+func sortBySpecs(t reflect.Type, fields []string) ([]sortBySpec, error) {
+	var specs []sortBySpec
+
+	if len(fields) > 0 {
+		for _, field := range fields {
+			name, dir, _ := strings.Cut(field, ",")
+			f, ok := t.FieldByName(name)
+			if !ok {
+				return nil, fmt.Errorf("SortBy: struct %s has no field %q", t, name)
+			}
+			desc, err := sortByDirection(dir)
+			if err != nil {
+				return nil, fmt.Errorf("SortBy: field %q: %w", field, err)
+			}
+			specs = append(specs, sortBySpec{name: name, index: f.Index, kind: f.Type.Kind(), desc: desc})
+		}
+	} else {
+		for _, f := range reflect.VisibleFields(t) {
+			tag, ok := f.Tag.Lookup("radix")
+			if !ok {
+				continue
+			}
+			prio, dir, _ := strings.Cut(tag, ",")
+			priority, err := strconv.Atoi(prio)
+			if err != nil {
+				return nil, fmt.Errorf("SortBy: field %s.%s: invalid radix tag %q", t, f.Name, tag)
+			}
+			desc, err := sortByDirection(dir)
+			if err != nil {
+				return nil, fmt.Errorf("SortBy: field %s.%s: %w", t, f.Name, err)
+			}
+			specs = append(specs, sortBySpec{name: f.Name, index: f.Index, kind: f.Type.Kind(), desc: desc, priority: priority})
+		}
+		if len(specs) == 0 {
+			return nil, fmt.Errorf("SortBy: no fields given and struct %s has no radix tags", t)
+		}
+		slices.SortStableFunc(specs, func(a, b sortBySpec) int { return a.priority - b.priority })
+	}
+
+	for _, spec := range specs {
+		switch spec.kind {
+		case reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+			reflect.Float32, reflect.Float64:
+		default:
+			return nil, fmt.Errorf("SortBy: field %s.%s has kind %s: %w", t, spec.name, spec.kind, ErrUnsupportedKind)
+		}
+	}
+
+	return specs, nil
+}
+
+// sortByDirection parses the optional direction part of a field or tag.
+func sortByDirection(dir string) (bool, error) {
+	switch strings.TrimSpace(dir) {
+	case "", "asc":
+		return false, nil
+	case "desc":
+		return true, nil
+	default:
+		return false, fmt.Errorf("unknown sort direction %q", dir)
+	}
+}
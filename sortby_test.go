@@ -0,0 +1,167 @@
+package radixsort_test
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"github.com/google/go-cmp/cmp"
+)
+
+type employee struct {
+	Name   string
+	Dept   uint8   `radix:"1"`
+	Salary float64 `radix:"2,desc"`
+	Age    int
+	Active bool
+}
+
+func TestSortBy(t *testing.T) {
+	in := []employee{
+		{Name: "Ann", Dept: 2, Salary: 100.5, Age: 30, Active: true},
+		{Name: "Bob", Dept: 1, Salary: -20, Age: 41},
+		{Name: "Cid", Dept: 2, Salary: 300, Age: 30},
+		{Name: "Dan", Dept: 1, Salary: 50, Age: -1, Active: true},
+		{Name: "Eve", Dept: 2, Salary: 100.5, Age: 25},
+	}
+
+	tests := []struct {
+		name   string
+		fields []string
+		want   []string
+	}{
+		{
+			name:   "single field",
+			fields: []string{"Age"},
+			want:   []string{"Dan", "Eve", "Ann", "Cid", "Bob"},
+		},
+		{
+			name:   "single field descending",
+			fields: []string{"Salary,desc"},
+			want:   []string{"Cid", "Ann", "Eve", "Dan", "Bob"},
+		},
+		{
+			name:   "composite key",
+			fields: []string{"Dept", "Age,desc"},
+			want:   []string{"Bob", "Dan", "Ann", "Cid", "Eve"},
+		},
+		{
+			name:   "stable on equal keys",
+			fields: []string{"Active"},
+			want:   []string{"Bob", "Cid", "Eve", "Ann", "Dan"},
+		},
+		{
+			name:   "struct tags",
+			fields: nil,
+			want:   []string{"Dan", "Bob", "Cid", "Ann", "Eve"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := append([]employee{}, in...)
+
+			err := radixsort.SortBy(data, tt.fields...)
+			if err != nil {
+				t.Fatalf("SortBy failed: %v", err)
+			}
+
+			got := make([]string, len(data))
+			for i, e := range data {
+				got[i] = e.Name
+			}
+
+			if !cmp.Equal(tt.want, got) {
+				t.Errorf("case: %s; SortBy(%v) = %v, want %v", tt.name, tt.fields, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSortByPointers(t *testing.T) {
+	data := []*employee{{Name: "b", Age: 2}, {Name: "c", Age: 3}, {Name: "a", Age: 1}}
+
+	err := radixsort.SortBy(data, "Age")
+	if err != nil {
+		t.Fatalf("SortBy failed: %v", err)
+	}
+
+	for i, want := range []string{"a", "b", "c"} {
+		if data[i].Name != want {
+			t.Errorf("SortBy: data[%d].Name = %q, want %q", i, data[i].Name, want)
+		}
+	}
+}
+
+func TestSortByRandom(t *testing.T) {
+	type record struct {
+		A int16
+		B uint32
+		C float32
+	}
+
+	data := make([]record, 100_000)
+	for i := range data {
+		data[i] = record{A: int16(rand.Intn(16) - 8), B: uint32(rand.Intn(64)), C: rand.Float32() - 0.5}
+	}
+
+	want := append([]record{}, data...)
+	slices.SortStableFunc(want, func(x, y record) int {
+		switch {
+		case x.A != y.A:
+			return int(x.A) - int(y.A)
+		case x.B != y.B:
+			return int(y.B) - int(x.B)
+		case x.C < y.C:
+			return -1
+		case x.C > y.C:
+			return 1
+		}
+		return 0
+	})
+
+	err := radixsort.SortBy(data, "A", "B,desc", "C")
+	if err != nil {
+		t.Fatalf("SortBy failed: %v", err)
+	}
+
+	if !cmp.Equal(want, data) {
+		t.Errorf("SortBy result differs from slices.SortStableFunc")
+	}
+}
+
+func TestSortByErrors(t *testing.T) {
+	type untagged struct{ X int }
+	type Base struct{ Rank int }
+	type derived struct{ *Base }
+
+	tests := []struct {
+		name    string
+		data    any
+		fields  []string
+		wantErr error
+	}{
+		{name: "not a slice", data: employee{}, fields: []string{"Age"}},
+		{name: "not structs", data: []int{3, 1, 2}, fields: []string{"Age"}},
+		{name: "unknown field", data: []employee{}, fields: []string{"Height"}},
+		{name: "unknown direction", data: []employee{}, fields: []string{"Age,up"}},
+		{name: "no tags", data: []untagged{{1}, {2}}},
+		{name: "unsupported kind", data: []employee{}, fields: []string{"Name"}, wantErr: radixsort.ErrUnsupportedKind},
+		{name: "nil element", data: []*employee{{}, nil}, fields: []string{"Age"}},
+		{name: "nil embedded pointer", data: []derived{{&Base{1}}, {}}, fields: []string{"Rank"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := radixsort.SortBy(tt.data, tt.fields...)
+			if err == nil {
+				t.Fatalf("SortBy: expected error, got nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("SortBy: error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package radixsort
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// Sorter sorts slices without a caller-provided buffer. It owns the scratch
+// memory of the sorts and reuses it across calls.
+//
+// The buffers are kept in one sync.Pool per key width, so a Sorter is safe
+// for concurrent use: every goroutine takes its own buffer from the pool and
+// returns it when the sort is done, and the garbage collector may release
+// pooled buffers that are not in use. A buffer that is too short for the
+// input is replaced by a longer one, so the retained buffers grow to the
+// longest inputs sorted.
+//
+// The zero value is ready to use. Most programs can share [DefaultSorter].
+// A Sorter must not be copied after first use.
+//
+// Example:
+//
+//	var s radixsort.Sorter
+//	s.Uint64(data)
+type Sorter struct {
+	// MaxBufferLen is the length of the longest buffer kept for reuse; zero
+	// means no limit. Inputs longer than that are sorted with a temporary
+	// buffer, which keeps a single large sort from pinning its memory.
+	MaxBufferLen int
+
+	pool16, pool32, pool64 sync.Pool
+	// generic maps the reflect.Type of the elements sorted by GenericPooled
+	// to the *sync.Pool of their buffers.
+	generic sync.Map
+}
+
+// DefaultSorter is the Sorter shared by the whole process.
+var DefaultSorter = &Sorter{}
+
+// Uint64 sorts a slice of uint64 values in ascending order like [Uint64].
+func (s *Sorter) Uint64(data []uint64) {
+	withBuffer(s, &s.pool64, len(data), func(buf []uint64) { _ = Uint64(data, buf) })
+}
+
+// Uint32 sorts a slice of uint32 values in ascending order like [Uint32].
+func (s *Sorter) Uint32(data []uint32) {
+	withBuffer(s, &s.pool32, len(data), func(buf []uint32) { _ = Uint32(data, buf) })
+}
+
+// Uint16 sorts a slice of uint16 values in ascending order like [Uint16].
+func (s *Sorter) Uint16(data []uint16) {
+	withBuffer(s, &s.pool16, len(data), func(buf []uint16) { _ = Uint16(data, buf) })
+}
+
+// Uint8 sorts a slice of uint8 values in ascending order. Counting sort needs
+// no buffer, see [InPlaceUint8].
+func (s *Sorter) Uint8(data []uint8) {
+	InPlaceUint8(data)
+}
+
+// Int64 sorts a slice of int64 values in ascending order like [Int64].
+func (s *Sorter) Int64(data []int64) {
+	withBuffer(s, &s.pool64, len(data), func(buf []uint64) { _ = Int64(data, buf) })
+}
+
+// Int32 sorts a slice of int32 values in ascending order like [Int32].
+func (s *Sorter) Int32(data []int32) {
+	withBuffer(s, &s.pool32, len(data), func(buf []uint32) { _ = Int32(data, buf) })
+}
+
+// Int16 sorts a slice of int16 values in ascending order like [Int16].
+func (s *Sorter) Int16(data []int16) {
+	withBuffer(s, &s.pool16, len(data), func(buf []uint16) { _ = Int16(data, buf) })
+}
+
+// Int8 sorts a slice of int8 values in ascending order. Counting sort needs
+// no buffer, see [InPlaceInt8].
+func (s *Sorter) Int8(data []int8) {
+	InPlaceInt8(data)
+}
+
+// Float64 sorts a slice of float64 values in the order of [OrderedKey], like
+// [Parallel.Float64] on the calling goroutine.
+func (s *Sorter) Float64(data []float64) {
+	keys := *(*[]uint64)(unsafe.Pointer(&data))
+	floatKeys(keys, 1)
+	s.Uint64(keys)
+	floatValues(keys, 1)
+}
+
+// Float32 sorts a slice of float32 values in the order of [OrderedKey], like
+// [Parallel.Float32] on the calling goroutine.
+func (s *Sorter) Float32(data []float32) {
+	keys := *(*[]uint32)(unsafe.Pointer(&data))
+	floatKeys(keys, 1)
+	s.Uint32(keys)
+	floatValues(keys, 1)
+}
+
+// GenericPooled sorts a slice of elements by a numeric key like [Generic],
+// with a buffer taken from s. It is a function rather than a method of
+// [Sorter] because Go methods cannot have type parameters.
+//
+// The buffers are pooled per element type.
+//
+// Example:
+//
+//	radixsort.GenericPooled(radixsort.DefaultSorter, items, func(i Item) float64 {
+//	    return i.Score
+//	})
+func GenericPooled[E any, N ConstraintNumbers](s *Sorter, data []E, key func(a E) N) {
+	pool, ok := s.generic.Load(reflect.TypeFor[E]())
+	if !ok {
+		pool, _ = s.generic.LoadOrStore(reflect.TypeFor[E](), new(sync.Pool))
+	}
+	withBuffer(s, pool.(*sync.Pool), len(data), func(buf []E) {
+		_ = Generic(data, buf, key)
+		// Drop the references that the elements may hold, so that the
+		// pooled buffer does not keep them alive.
+		clear(buf)
+	})
+}
+
+// withBuffer calls sort with a buffer of at least n elements from pool and
+// returns the buffer to the pool afterwards, unless it is longer than
+// s.MaxBufferLen.
+func withBuffer[T any](s *Sorter, pool *sync.Pool, n int, sort func(buf []T)) {
+	if n < 2 {
+		return
+	}
+
+	bufp, _ := pool.Get().(*[]T)
+	if bufp == nil || cap(*bufp) < n {
+		if bufp != nil {
+			// Keep the shorter buffer for shorter inputs.
+			pool.Put(bufp)
+		}
+		buf := make([]T, n)
+		bufp = &buf
+	}
+
+	sort((*bufp)[:n])
+
+	if s.MaxBufferLen > 0 && cap(*bufp) > s.MaxBufferLen {
+		return
+	}
+	pool.Put(bufp)
+}
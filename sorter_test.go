@@ -0,0 +1,111 @@
+package radixsort_test
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+func TestSorter(t *testing.T) {
+	var s radixsort.Sorter
+	for _, size := range []int{0, 1, 100, 1000, 100_000} {
+		u64 := randomUint64(size)
+		s.Uint64(u64)
+		if !slices.IsSorted(u64) {
+			t.Errorf("Uint64 of %d elements failed", size)
+		}
+
+		i32 := make([]int32, size)
+		u16 := make([]uint16, size)
+		i8 := make([]int8, size)
+		f64 := make([]float64, size)
+		for i := range size {
+			i32[i] = rand.Int31() - 1<<30
+			u16[i] = uint16(rand.Uint32())
+			i8[i] = int8(rand.Uint32())
+			f64[i] = rand.NormFloat64()
+		}
+		s.Int32(i32)
+		s.Uint16(u16)
+		s.Int8(i8)
+		s.Float64(f64)
+		if !slices.IsSorted(i32) || !slices.IsSorted(u16) || !slices.IsSorted(i8) || !slices.IsSorted(f64) {
+			t.Errorf("sort of %d elements failed", size)
+		}
+	}
+}
+
+func TestSorterConcurrent(t *testing.T) {
+	// Run with -race: every goroutine must sort with a buffer of its own.
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 20 {
+				data := randomUint64(1000 * (g + i + 1))
+				radixsort.DefaultSorter.Uint64(data)
+				if !slices.IsSorted(data) {
+					t.Error("concurrent Uint64 failed")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSorterReusesBuffers(t *testing.T) {
+	var s radixsort.Sorter
+	data := randomUint64(10_000)
+	s.Uint64(slices.Clone(data))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		s.Uint64(data)
+	})
+	// The pool may drop its buffer in a garbage collection now and then.
+	if allocs >= 1 {
+		t.Errorf("Uint64 allocated %v times per sort, want a reused buffer", allocs)
+	}
+
+	limited := radixsort.Sorter{MaxBufferLen: 100}
+	data = randomUint64(10_000)
+	limited.Uint64(data)
+	if !slices.IsSorted(data) {
+		t.Error("Uint64 with MaxBufferLen failed")
+	}
+}
+
+func TestGenericPooled(t *testing.T) {
+	type item struct {
+		name *string
+		key  int16
+	}
+	data := make([]item, 20_000)
+	for i := range data {
+		name := string(rune('a' + i%26))
+		data[i] = item{name: &name, key: int16(rand.Intn(100))}
+	}
+	want := slices.Clone(data)
+	slices.SortStableFunc(want, func(a, b item) int { return cmp.Compare(a.key, b.key) })
+
+	radixsort.GenericPooled(radixsort.DefaultSorter, data, func(e item) int16 { return e.key })
+	if !slices.Equal(want, data) {
+		t.Error("GenericPooled failed to sort data stably")
+	}
+
+	// Another element type with the same layout gets its own pool.
+	type other struct {
+		name *string
+		key  int16
+	}
+	others := []other{{key: 2}, {key: 1}}
+	radixsort.GenericPooled(radixsort.DefaultSorter, others, func(e other) int16 { return e.key })
+	if others[0].key != 1 {
+		t.Error("GenericPooled failed on a second element type")
+	}
+}
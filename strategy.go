@@ -0,0 +1,287 @@
+package radixsort
+
+import (
+	"fmt"
+	"slices"
+	"unsafe"
+
+	"github.com/sagernet/sing/common/x/constraints"
+)
+
+// Strategy identifies the algorithm that a sort chooses for its input.
+type Strategy uint8
+
+const (
+	// StrategyInsertion sorts short inputs with insertion sort.
+	StrategyInsertion Strategy = iota
+	// StrategyComparison sorts with the pattern-defeating quicksort of the
+	// standard library, which wins on keys with very few distinct values
+	// and on nearly sorted keys of moderate length.
+	StrategyComparison
+	// StrategyCounting counts the values of a narrow key range and writes
+	// them back in order.
+	StrategyCounting
+	// StrategyLSD runs the LSD radix passes, including the detection of
+	// sorted, reversed and merged runs in their histogram pass.
+	StrategyLSD
+	// StrategyMSD partitions the data in place by its most significant
+	// bytes, see [InPlaceUint64]. It is also the strategy of the bounded
+	// memory mode, which finishes the buckets with the LSD passes.
+	StrategyMSD
+	// StrategyHybrid partitions long 64-bit inputs into cache-sized buckets
+	// before their LSD passes.
+	StrategyHybrid
+)
+
+var strategyNames = [...]string{
+	StrategyInsertion:  "insertion",
+	StrategyComparison: "comparison",
+	StrategyCounting:   "counting",
+	StrategyLSD:        "lsd",
+	StrategyMSD:        "msd",
+	StrategyHybrid:     "hybrid",
+}
+
+// String returns the lower-case name of s.
+func (s Strategy) String() string {
+	if int(s) < len(strategyNames) {
+		return strategyNames[s]
+	}
+	return fmt.Sprintf("Strategy(%d)", s)
+}
+
+// Decision describes how a sort processes its input and the sample that the
+// choice was based on. It is returned by the Plan functions, such as
+// [PlanUint64], for debugging and tuning.
+type Decision struct {
+	// Strategy is the algorithm chosen.
+	Strategy Strategy
+	// Len is the number of elements to sort.
+	Len int
+	// Sampled is the number of evenly spaced elements inspected, or zero if
+	// the input was too short or the buffer too short to sample.
+	Sampled int
+	// Distinct is the number of distinct keys among the samples.
+	Distinct int
+	// Descents is the number of samples smaller than the sample before them:
+	// zero for sorted input, Sampled-1 for reversed input and about half of
+	// Sampled for random input.
+	Descents int
+	// Range is the difference between the largest and the smallest sampled
+	// key, in the order of the sort.
+	Range uint64
+
+	// minimum and span are the exact key range, known only for
+	// StrategyCounting.
+	minimum, span uint64
+}
+
+// String formats d for logs, e.g. "msd: 100000 elements, 256 sampled,
+// 64 distinct, 130 descents, range 0xffd2c1a0b3e4f501".
+func (d Decision) String() string {
+	if d.Sampled == 0 {
+		return fmt.Sprintf("%v: %d elements", d.Strategy, d.Len)
+	}
+	return fmt.Sprintf("%v: %d elements, %d sampled, %d distinct, %d descents, range %#x",
+		d.Strategy, d.Len, d.Sampled, d.Distinct, d.Descents, d.Range)
+}
+
+// Sampling parameters of planKeys.
+const (
+	// sampleLen is the number of elements sampled.
+	sampleLen = 256
+	// sampleMinLen is the shortest input that is sampled. Below it, the
+	// sample would cost a noticeable part of the sort.
+	sampleMinLen = 1 << 12
+	// nearlySortedDescents is the largest number of descents in the sample
+	// of a nearly sorted input. Random keys have about sampleLen/2.
+	nearlySortedDescents = sampleLen / 16
+	// fewDistinct is the largest number of distinct sampled keys for which
+	// the comparison sort beats the radix sorts.
+	fewDistinct = 16
+	// duplicateDistinct is the largest number of distinct sampled keys for
+	// which MSD beats LSD: repeated keys end up in few buckets, which are
+	// finished after the bytes that tell them apart.
+	duplicateDistinct = sampleLen * 3 / 4
+)
+
+// PlanUint64 reports how [Uint64] sorts data with buf, without modifying
+// either of them.
+//
+// Long inputs are sampled at 256 evenly spaced positions. The sample
+// estimates the key range, the number of distinct keys and how far the input
+// is from sorted, which select the algorithm (as measured on 64-bit keys
+// between 4K and 512K elements):
+//
+//   - Counting sort, if the exact range turns out to be dense.
+//   - The LSD passes for sorted and reversed inputs, which they detect, and
+//     for keys that vary in at most two bytes.
+//   - The comparison sort for nearly sorted inputs and for very few
+//     distinct keys; MSD for nearly sorted inputs longer than the cache.
+//   - MSD for many duplicates.
+//   - LSD, or hybrid MSD+LSD for inputs longer than the cache, otherwise.
+//
+// Example:
+//
+//	log.Printf("sorting: %v", radixsort.PlanUint64(data, buf))
+func PlanUint64(data, buf []uint64) Decision {
+	return planKeys(data, len(buf), 0)
+}
+
+// PlanUint32 reports how [Uint32] sorts data with buf, without modifying
+// either of them.
+//
+// The 32-bit sorts choose between insertion, counting, LSD and bounded MSD
+// sorts only: their LSD passes were faster than the alternatives on every
+// sampled distribution. See [PlanUint64].
+func PlanUint32(data, buf []uint32) Decision {
+	return planKeys(data, len(buf), 0)
+}
+
+// PlanInt64 reports how [Int64] sorts data with buf, without modifying
+// either of them. See [PlanUint64].
+func PlanInt64(data []int64, buf []uint64) Decision {
+	return planKeys(*(*[]uint64)(unsafe.Pointer(&data)), len(buf), 1<<63)
+}
+
+// PlanInt32 reports how [Int32] sorts data with buf, without modifying
+// either of them. See [PlanUint32].
+func PlanInt32(data []int32, buf []uint32) Decision {
+	return planKeys(*(*[]uint32)(unsafe.Pointer(&data)), len(buf), 1<<31)
+}
+
+// planKeys chooses the strategy for sorting data, integers in their unsigned
+// representation with the sign bit flip, with a buffer of bufLen elements.
+func planKeys[T constraints.Unsigned](data []T, bufLen int, flip T) Decision {
+	n := len(data)
+	d := Decision{Len: n}
+	size := unsafe.Sizeof(flip)
+	switch {
+	case bufLen < n:
+		d.Strategy = StrategyMSD
+		return d
+	case isSmall(n, size):
+		d.Strategy = StrategyInsertion
+		return d
+	}
+
+	d.Strategy = StrategyLSD
+	if size == 8 && n >= hybridMinLen.get() {
+		d.Strategy = StrategyHybrid
+	}
+	if n < sampleMinLen {
+		return d
+	}
+
+	lo, hi, diff := sampleKeys(data, flip, &d)
+	d.Range = uint64(hi - lo)
+
+	switch {
+	case d.Descents == 0 || d.Descents == d.Sampled-1:
+		// The LSD passes detect sorted and reversed input.
+	case d.Range < countingMaxRange && uint64(n) >= countingDensity*(d.Range+1) && exactRange(data, flip, &d):
+		d.Strategy = StrategyCounting
+	case size < 8 || varyingBytes(uint64(diff)) <= 2:
+		// At most two LSD passes, or 32-bit keys, whose LSD passes won on
+		// every distribution.
+	case d.Descents <= nearlySortedDescents && n >= hybridMinLen.get():
+		d.Strategy = StrategyMSD
+	case d.Descents <= nearlySortedDescents:
+		d.Strategy = StrategyComparison
+	case n >= hybridMinLen.get():
+		// Duplicates no longer pay off against the cache-sized buckets.
+	case d.Distinct <= fewDistinct:
+		d.Strategy = StrategyComparison
+	case d.Distinct <= duplicateDistinct:
+		d.Strategy = StrategyMSD
+	}
+	return d
+}
+
+// sampleKeys records the statistics of sampleLen evenly spaced keys of data
+// in d and returns their minimum, maximum and the bits in which they differ.
+func sampleKeys[T constraints.Unsigned](data []T, flip T, d *Decision) (lo, hi, diff T) {
+	// Distinct keys are counted in an open-addressing hash set.
+	var set [2 * sampleLen]T
+	var used [2 * sampleLen]bool
+
+	step := len(data) / sampleLen
+	first := data[0] ^ flip
+	lo, hi, prev := first, first, first
+	for i := range sampleLen {
+		v := data[i*step] ^ flip
+		lo, hi = min(lo, v), max(hi, v)
+		diff |= v ^ first
+		if v < prev {
+			d.Descents++
+		}
+		prev = v
+
+		h := uint(uint64(v) * 0x9e3779b97f4a7c15 >> 55)
+		for used[h] && set[h] != v {
+			h = (h + 1) % uint(len(set))
+		}
+		if !used[h] {
+			used[h], set[h] = true, v
+			d.Distinct++
+		}
+	}
+	d.Sampled = sampleLen
+	return lo, hi, diff
+}
+
+// exactRange scans data for its exact key range, stores it in d and reports
+// whether it is narrow enough for counting sort.
+func exactRange[T constraints.Unsigned](data []T, flip T, d *Decision) bool {
+	lo, hi := data[0]^flip, data[0]^flip
+	for _, v := range data {
+		lo, hi = min(lo, v^flip), max(hi, v^flip)
+	}
+	span := uint64(hi - lo)
+	if span >= countingMaxRange || uint64(len(data)) < countingDensity*(span+1) {
+		return false
+	}
+	d.minimum, d.span = uint64(lo), span
+	return true
+}
+
+// sortSampled sorts data, integers in their unsigned representation with the
+// sign bit flip, if planKeys chooses a strategy other than the radix sorts of
+// the entry points, and reports whether it did.
+func sortSampled[T constraints.Unsigned](data, buf []T, flip T) bool {
+	if len(buf) < len(data) {
+		return false
+	}
+
+	d := planKeys(data, len(buf), flip)
+	switch d.Strategy {
+	case StrategyCounting:
+		// The counters are kept in buf, which holds four elements per counter
+		// and fits them unless it is misaligned; then the radix sorts run.
+		counts := counters(buf)
+		if uint64(len(counts)) <= d.span {
+			return false
+		}
+		counts = counts[:d.span+1]
+		clear(counts)
+		// Flipping the sign bit is the same as adding it, so the keys
+		// minimum..minimum+span are the values from minimum^flip upwards.
+		countFillWrapped(data, T(d.minimum)^flip, counts)
+	case StrategyComparison, StrategyMSD:
+		// Flipping the sign bit orders negative values first.
+		if flip != 0 {
+			flipBits(data, flip)
+		}
+		if d.Strategy == StrategyComparison {
+			slices.Sort(data)
+		} else {
+			americanFlag(data, nil, uint(unsafe.Sizeof(flip)-1)*8, nil)
+		}
+		if flip != 0 {
+			flipBits(data, flip)
+		}
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,205 @@
+package radixsort_test
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+)
+
+func TestPlanAndSortUint64(t *testing.T) {
+	fewValues := func(n, distinct int) []uint64 {
+		values := randomUint64(distinct)
+		data := make([]uint64, n)
+		for i := range data {
+			data[i] = values[rand.Intn(distinct)]
+		}
+		return data
+	}
+	denseUint64 := func(n int) []uint64 {
+		data := make([]uint64, n)
+		for i := range data {
+			data[i] = 1<<40 + uint64(rand.Intn(5000))
+		}
+		return data
+	}
+	nearlySorted := func(n int) []uint64 {
+		// A fixed seed, since a sample may miss the few displaced elements.
+		r := rand.New(rand.NewSource(1))
+		data := sortedUint64(n)
+		for range n / 100 {
+			i, j := r.Intn(n), r.Intn(n)
+			data[i], data[j] = data[j], data[i]
+		}
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []uint64
+		want radixsort.Strategy
+	}{
+		{"short", randomUint64(100), radixsort.StrategyInsertion},
+		{"unsampled", randomUint64(1000), radixsort.StrategyLSD},
+		{"random", randomUint64(50_000), radixsort.StrategyLSD},
+		{"random_long", randomUint64(1 << 19), radixsort.StrategyHybrid},
+		{"sorted", sortedUint64(50_000), radixsort.StrategyLSD},
+		{"descending", descendingUint64(50_000), radixsort.StrategyLSD},
+		{"dense", denseUint64(50_000), radixsort.StrategyCounting},
+		{"narrow", narrowUint64(50_000), radixsort.StrategyLSD},
+		{"nearly_sorted", nearlySorted(50_000), radixsort.StrategyComparison},
+		{"nearly_sorted_long", nearlySorted(1 << 19), radixsort.StrategyMSD},
+		{"few_distinct", fewValues(50_000, 8), radixsort.StrategyComparison},
+		{"duplicates", fewValues(50_000, 100), radixsort.StrategyMSD},
+		{"duplicates_long", fewValues(1<<19, 100), radixsort.StrategyHybrid},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			buf := make([]uint64, len(tc.data))
+			d := radixsort.PlanUint64(tc.data, buf)
+			if d.Strategy != tc.want {
+				t.Errorf("strategy = %v, want %v (%v)", d.Strategy, tc.want, d)
+			}
+			if d.Len != len(tc.data) {
+				t.Errorf("Len = %d, want %d", d.Len, len(tc.data))
+			}
+
+			want := slices.Clone(tc.data)
+			slices.Sort(want)
+			if err := radixsort.Uint64(tc.data, buf); err != nil {
+				t.Fatalf("Uint64 failed: %v", err)
+			}
+			if !slices.Equal(want, tc.data) {
+				t.Error("Uint64 failed to sort data correctly")
+			}
+		})
+	}
+}
+
+func TestPlanAndSortSigned(t *testing.T) {
+	const size = 50_000
+
+	// The counted range wraps around at the sign bit of the unsigned
+	// representation.
+	around64 := make([]int64, size)
+	for i := range around64 {
+		around64[i] = int64(rand.Intn(2000) - 1000)
+	}
+	few64 := make([]int64, size)
+	for i := range few64 {
+		few64[i] = []int64{-1 << 62, -5, 3, 1 << 60}[rand.Intn(4)]
+	}
+	spread64 := make([]int64, size)
+	for i := range spread64 {
+		spread64[i] = rand.Int63() - 1<<62
+	}
+	around32 := make([]int32, size)
+	for i := range around32 {
+		around32[i] = int32(rand.Intn(2000) - 1000)
+	}
+
+	tests64 := []struct {
+		name string
+		data []int64
+		want radixsort.Strategy
+	}{
+		{"around_zero", around64, radixsort.StrategyCounting},
+		{"few_distinct", few64, radixsort.StrategyComparison},
+		{"random", spread64, radixsort.StrategyLSD},
+	}
+	for _, tc := range tests64 {
+		t.Run("Int64_"+tc.name, func(t *testing.T) {
+			buf := make([]uint64, len(tc.data))
+			if d := radixsort.PlanInt64(tc.data, buf); d.Strategy != tc.want {
+				t.Errorf("strategy = %v, want %v (%v)", d.Strategy, tc.want, d)
+			}
+			want := slices.Clone(tc.data)
+			slices.Sort(want)
+			if err := radixsort.Int64(tc.data, buf); err != nil {
+				t.Fatalf("Int64 failed: %v", err)
+			}
+			if !slices.Equal(want, tc.data) {
+				t.Error("Int64 failed to sort data correctly")
+			}
+		})
+	}
+
+	t.Run("Int32_around_zero", func(t *testing.T) {
+		buf := make([]uint32, size)
+		if d := radixsort.PlanInt32(around32, buf); d.Strategy != radixsort.StrategyCounting {
+			t.Errorf("strategy = %v, want %v (%v)", d.Strategy, radixsort.StrategyCounting, d)
+		}
+		want := slices.Clone(around32)
+		slices.Sort(want)
+		if err := radixsort.Int32(around32, buf); err != nil {
+			t.Fatalf("Int32 failed: %v", err)
+		}
+		if !slices.Equal(want, around32) {
+			t.Error("Int32 failed to sort data correctly")
+		}
+	})
+}
+
+func TestPlanBufferAndString(t *testing.T) {
+	data := make([]uint32, 50_000)
+	for i := range data {
+		data[i] = rand.Uint32()
+	}
+
+	if d := radixsort.PlanUint32(data, make([]uint32, 1000)); d.Strategy != radixsort.StrategyMSD || d.Sampled != 0 {
+		t.Errorf("short buffer: got %v, want unsampled %v", d, radixsort.StrategyMSD)
+	}
+
+	d := radixsort.PlanUint32(data, make([]uint32, len(data)))
+	if d.Strategy != radixsort.StrategyLSD || d.Sampled == 0 || d.Distinct > d.Sampled {
+		t.Errorf("full buffer: got %+v", d)
+	}
+	if s := d.String(); !strings.HasPrefix(s, "lsd: 50000 elements, 256 sampled") {
+		t.Errorf("String() = %q", s)
+	}
+	if s := radixsort.Strategy(100).String(); s != "Strategy(100)" {
+		t.Errorf("String() = %q, want %q", s, "Strategy(100)")
+	}
+}
+
+func TestSampledCountingAllocs(t *testing.T) {
+	const size = 50_000
+	input := make([]int64, size)
+	for i := range input {
+		input[i] = int64(rand.Intn(2000) - 1000)
+	}
+	s64, u64, buf64 := make([]int64, size), make([]uint64, size), make([]uint64, size)
+	s32, u32, buf32 := make([]int32, size), make([]uint32, size), make([]uint32, size)
+
+	tests := []struct {
+		name   string
+		sort   func()
+		sorted func() bool
+	}{
+		{name: "Uint64", sort: func() { _ = radixsort.Uint64(u64, buf64) }, sorted: func() bool { return slices.IsSorted(u64) }},
+		{name: "Int64", sort: func() { _ = radixsort.Int64(s64, buf64) }, sorted: func() bool { return slices.IsSorted(s64) }},
+		{name: "Uint32", sort: func() { _ = radixsort.Uint32(u32, buf32) }, sorted: func() bool { return slices.IsSorted(u32) }},
+		{name: "Int32", sort: func() { _ = radixsort.Int32(s32, buf32) }, sorted: func() bool { return slices.IsSorted(s32) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(3, func() {
+				for i, v := range input {
+					s64[i], u64[i] = v, uint64(v+1000)
+					s32[i], u32[i] = int32(v), uint32(v+1000)
+				}
+				tt.sort()
+			})
+			if allocs != 0 {
+				t.Errorf("%s made %v allocations, want 0", tt.name, allocs)
+			}
+			if !tt.sorted() {
+				t.Errorf("%s failed to sort data correctly", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package radixsort
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Table sorts a set of equally long columns by one or more key columns,
+// like ORDER BY in a column-oriented database.
+//
+// Columns are registered with [Table.AddInt64], [Table.AddFloat64] and
+// [Table.AddString], and the sort keys are set with [Table.OrderBy].
+// [Table.Sort] computes a single stable permutation with radix passes over
+// the key columns and then reorders every registered column in place, so all
+// columns stay aligned row by row.
+//
+// The temporary buffers for the permutation and for each column type are
+// owned by the Table and reused across calls to Sort. The zero value is
+// ready to use. A Table must not be used concurrently.
+//
+// Example:
+//
+//	var t Table
+//	t.AddString("city", cities)
+//	t.AddInt64("year", years)
+//	t.AddFloat64("temp", temps)
+//	t.OrderBy("city", "temp,desc")
+//	err := t.Sort()
+type Table struct {
+	columns []tableColumn
+	orderBy []string
+
+	perm, permBuf []keyIndex
+	int64Buf      []int64
+	float64Buf    []float64
+	stringBuf     []string
+}
+
+// tableColumn is a registered column; exactly one of the slices is set.
+type tableColumn struct {
+	name     string
+	int64s   []int64
+	float64s []float64
+	strings  []string
+}
+
+func (c *tableColumn) len() int {
+	switch {
+	case c.int64s != nil:
+		return len(c.int64s)
+	case c.float64s != nil:
+		return len(c.float64s)
+	default:
+		return len(c.strings)
+	}
+}
+
+// AddInt64 registers an int64 column under name.
+// The column is reordered in place by [Table.Sort].
+func (t *Table) AddInt64(name string, col []int64) {
+	t.add(tableColumn{name: name, int64s: nonNil(col)})
+}
+
+// AddFloat64 registers a float64 column under name.
+// The column is reordered in place by [Table.Sort].
+func (t *Table) AddFloat64(name string, col []float64) {
+	t.add(tableColumn{name: name, float64s: nonNil(col)})
+}
+
+// AddString registers a string column under name.
+// The column is reordered in place by [Table.Sort].
+func (t *Table) AddString(name string, col []string) {
+	t.add(tableColumn{name: name, strings: nonNil(col)})
+}
+
+// add registers c, replacing a previously registered column with the same name.
+func (t *Table) add(c tableColumn) {
+	for i := range t.columns {
+		if t.columns[i].name == c.name {
+			t.columns[i] = c
+			return
+		}
+	}
+	t.columns = append(t.columns, c)
+}
+
+// nonNil keeps empty columns distinguishable from unset column slices.
+func nonNil[T any](col []T) []T {
+	if col == nil {
+		return []T{}
+	}
+	return col
+}
+
+// OrderBy sets the key columns, from the most to the least significant.
+// A name may be followed by ",desc" to sort that column in descending order.
+//
+// Numbers are ordered as by [OrderedKey]; strings are ordered bytewise,
+// like the < operator.
+func (t *Table) OrderBy(columns ...string) {
+	t.orderBy = append(t.orderBy[:0], columns...)
+}
+
+// Sort reorders all registered columns by the key columns set with
+// [Table.OrderBy]. Rows with equal keys keep their relative order.
+//
+// Returns an error if no key columns are set, a key column is not
+// registered, or the columns have different lengths.
+func (t *Table) Sort() error {
+	if len(t.orderBy) == 0 {
+		return fmt.Errorf("Table: no key columns set, call OrderBy first")
+	}
+
+	n := 0
+	for i, c := range t.columns {
+		if i == 0 {
+			n = c.len()
+		} else if c.len() != n {
+			return fmt.Errorf("Table: column %q has %d rows, want %d", c.name, c.len(), n)
+		}
+	}
+
+	type key struct {
+		col  *tableColumn
+		desc bool
+	}
+	keys := make([]key, len(t.orderBy))
+	for i, spec := range t.orderBy {
+		name, dir, _ := strings.Cut(spec, ",")
+		desc, err := sortByDirection(dir)
+		if err != nil {
+			return fmt.Errorf("Table: key %q: %w", spec, err)
+		}
+		for j := range t.columns {
+			if t.columns[j].name == name {
+				keys[i] = key{col: &t.columns[j], desc: desc}
+			}
+		}
+		if keys[i].col == nil {
+			return fmt.Errorf("Table: unknown key column %q", name)
+		}
+	}
+
+	if n < 2 {
+		return nil
+	}
+
+	t.perm = grow(t.perm, n)
+	t.permBuf = grow(t.permBuf, n)
+	perm := t.perm
+	for i := range perm {
+		perm[i].idx = i
+	}
+
+	// LSD over key columns: the least significant column is sorted first.
+	for i := len(keys) - 1; i >= 0; i-- {
+		t.sortByColumn(keys[i].col, keys[i].desc)
+	}
+
+	for i := range t.columns {
+		t.permute(&t.columns[i])
+	}
+
+	return nil
+}
+
+// sortByColumn stably reorders t.perm by the values of column c.
+func (t *Table) sortByColumn(c *tableColumn, desc bool) {
+	var mask uint64
+	if desc {
+		mask = ^uint64(0)
+	}
+
+	perm := t.perm
+	switch {
+	case c.int64s != nil:
+		for i := range perm {
+			perm[i].key = OrderedKey(c.int64s[perm[i].idx]) ^ mask
+		}
+		radixKeyIndex(perm, t.permBuf)
+	case c.float64s != nil:
+		for i := range perm {
+			perm[i].key = OrderedKey(c.float64s[perm[i].idx]) ^ mask
+		}
+		radixKeyIndex(perm, t.permBuf)
+	default:
+		maxLen := 0
+		for _, s := range c.strings {
+			maxLen = max(maxLen, len(s))
+		}
+		// Strings are sorted in 7-byte chunks, from the last chunk to the first.
+		// Each chunk key holds the bytes in its high 56 bits and the number of
+		// remaining bytes (capped at 8) in the low byte, so that a string sorts
+		// before any longer string sharing its prefix.
+		for p := (max(maxLen, 1) - 1) / 7 * 7; p >= 0; p -= 7 {
+			for i := range perm {
+				perm[i].key = stringChunkKey(c.strings[perm[i].idx], p) ^ mask
+			}
+			radixKeyIndex(perm, t.permBuf)
+		}
+	}
+}
+
+// stringChunkKey returns the key of the 7-byte chunk of s starting at p.
+func stringChunkKey(s string, p int) uint64 {
+	var k uint64
+	for i := range 7 {
+		k <<= 8
+		if p+i < len(s) {
+			k |= uint64(s[p+i])
+		}
+	}
+	return k<<8 | uint64(min(max(len(s)-p, 0), 8))
+}
+
+// permute reorders column c according to t.perm using the buffer of its type.
+func (t *Table) permute(c *tableColumn) {
+	switch {
+	case c.int64s != nil:
+		t.int64Buf = grow(t.int64Buf, len(t.perm))
+		permuteColumn(c.int64s, t.int64Buf, t.perm)
+	case c.float64s != nil:
+		t.float64Buf = grow(t.float64Buf, len(t.perm))
+		permuteColumn(c.float64s, t.float64Buf, t.perm)
+	default:
+		t.stringBuf = grow(t.stringBuf, len(t.perm))
+		permuteColumn(c.strings, t.stringBuf, t.perm)
+		clear(t.stringBuf)
+	}
+}
+
+// permuteColumn moves col[perm[i].idx] to col[i] using buf as scratch space.
+func permuteColumn[T any](col, buf []T, perm []keyIndex) {
+	for i, p := range perm {
+		buf[i] = col[p.idx]
+	}
+	copy(col, buf[:len(perm)])
+}
+
+// grow returns s resized to n elements, reallocating only if needed.
+func grow[T any](s []T, n int) []T {
+	if cap(s) < n {
+		return make([]T, n)
+	}
+	return s[:n]
+}
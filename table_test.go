@@ -0,0 +1,197 @@
+package radixsort_test
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	gocmp "github.com/google/go-cmp/cmp"
+)
+
+func TestTable(t *testing.T) {
+	cities := []string{"Paris", "Oslo", "Paris", "Lima", "Oslo", "Oslo"}
+	years := []int64{2021, 2020, 2019, 2021, 2021, 2019}
+	temps := []float64{12.5, -3, 14, 19.5, -3, 1.25}
+
+	tests := []struct {
+		name    string
+		orderBy []string
+		want    []int // original row indexes in sorted order
+	}{
+		{
+			name:    "int64 key",
+			orderBy: []string{"year"},
+			want:    []int{2, 5, 1, 0, 3, 4},
+		},
+		{
+			name:    "float64 key descending",
+			orderBy: []string{"temp,desc"},
+			want:    []int{3, 2, 0, 5, 1, 4},
+		},
+		{
+			name:    "string key",
+			orderBy: []string{"city"},
+			want:    []int{3, 1, 4, 5, 0, 2},
+		},
+		{
+			name:    "composite key",
+			orderBy: []string{"city,desc", "year,desc", "temp"},
+			want:    []int{0, 2, 4, 1, 5, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := []int64{0, 1, 2, 3, 4, 5}
+			c := append([]string{}, cities...)
+			y := append([]int64{}, years...)
+			tm := append([]float64{}, temps...)
+
+			var table radixsort.Table
+			table.AddString("city", c)
+			table.AddInt64("year", y)
+			table.AddFloat64("temp", tm)
+			table.AddInt64("row", rows)
+			table.OrderBy(tt.orderBy...)
+
+			if err := table.Sort(); err != nil {
+				t.Fatalf("Table.Sort failed: %v", err)
+			}
+
+			for i, r := range rows {
+				if r != int64(tt.want[i]) {
+					t.Fatalf("case: %s; row order = %v, want %v", tt.name, rows, tt.want)
+				}
+				if c[i] != cities[r] || y[i] != years[r] || tm[i] != temps[r] {
+					t.Fatalf("case: %s; columns are not aligned at row %d", tt.name, i)
+				}
+			}
+		})
+	}
+}
+
+func TestTableStrings(t *testing.T) {
+	in := []string{"", "b", "abcdefgh", "abcdefg", "abcdefgh\x00", "abc", "a", "", "abcdefghijklmnopq", "abcdefghijklmnop", "\xff", "abcdefg\x00"}
+
+	for _, desc := range []bool{false, true} {
+		data := append([]string{}, in...)
+
+		var table radixsort.Table
+		table.AddString("s", data)
+		if desc {
+			table.OrderBy("s,desc")
+		} else {
+			table.OrderBy("s")
+		}
+
+		if err := table.Sort(); err != nil {
+			t.Fatalf("Table.Sort failed: %v", err)
+		}
+
+		want := append([]string{}, in...)
+		slices.Sort(want)
+		if desc {
+			slices.Reverse(want)
+		}
+
+		if !gocmp.Equal(want, data) {
+			t.Errorf("Table.Sort(desc=%v) = %q, want %q", desc, data, want)
+		}
+	}
+}
+
+func TestTableRandom(t *testing.T) {
+	const size = 50_000
+
+	names := make([]string, size)
+	scores := make([]float64, size)
+	ids := make([]int64, size)
+	for i := range size {
+		names[i] = strings.Repeat("x", rand.Intn(3)) + fmt.Sprint(rand.Intn(50))
+		scores[i] = float64(rand.Intn(20)) - 10
+		ids[i] = int64(i)
+	}
+
+	type row struct {
+		name  string
+		score float64
+		id    int64
+	}
+	want := make([]row, size)
+	for i := range want {
+		want[i] = row{names[i], scores[i], ids[i]}
+	}
+	slices.SortStableFunc(want, func(a, b row) int {
+		if c := cmp.Compare(a.name, b.name); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.score, a.score)
+	})
+
+	var table radixsort.Table
+	table.AddString("name", names)
+	table.AddFloat64("score", scores)
+	table.AddInt64("id", ids)
+	table.OrderBy("name", "score,desc")
+
+	// Sorting twice also exercises the buffer reuse.
+	for range 2 {
+		if err := table.Sort(); err != nil {
+			t.Fatalf("Table.Sort failed: %v", err)
+		}
+	}
+
+	for i, w := range want {
+		if names[i] != w.name || scores[i] != w.score || ids[i] != w.id {
+			t.Fatalf("Table.Sort: row %d = {%s %v %d}, want %v", i, names[i], scores[i], ids[i], w)
+		}
+	}
+}
+
+func TestTableErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(*radixsort.Table)
+	}{
+		{
+			name:  "no keys",
+			setup: func(tb *radixsort.Table) { tb.AddInt64("a", []int64{1}) },
+		},
+		{
+			name: "unknown key",
+			setup: func(tb *radixsort.Table) {
+				tb.AddInt64("a", []int64{1})
+				tb.OrderBy("b")
+			},
+		},
+		{
+			name: "bad direction",
+			setup: func(tb *radixsort.Table) {
+				tb.AddInt64("a", []int64{1})
+				tb.OrderBy("a,sideways")
+			},
+		},
+		{
+			name: "length mismatch",
+			setup: func(tb *radixsort.Table) {
+				tb.AddInt64("a", []int64{1, 2})
+				tb.AddString("b", []string{"x"})
+				tb.OrderBy("a")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var table radixsort.Table
+			tt.setup(&table)
+			if err := table.Sort(); err == nil {
+				t.Errorf("Table.Sort: expected error, got nil")
+			}
+		})
+	}
+}
@@ -3,15 +3,26 @@ package radixsort
 // Uint16 sorts a slice of uint16 values in ascending order.
 //
 // The data slice is sorted in place. The buf slice is used for temporary
-// storage during sorting and must have len(buf) >= len(data).
+// storage during sorting. A buffer shorter than the data is accepted down to
+// [MinBufferSize] elements; the sort is then slower but uses no more memory.
 //
 // The buffer can be reused across multiple sort operations without clearing.
 //
-// Returns ErrInvalidBufferSize if len(buf) < len(data).
+// Long inputs whose values span a narrow range, at least four elements per
+// possible value, are sorted by counting, with the counters kept in the
+// buffer instead of moving the elements through it.
+//
+// Returns ErrInvalidBufferSize if len(buf) < min(len(data), MinBufferSize).
 //
 // See [Uint64] for the 64-bit version and for usage example.
 func Uint16(data, buf []uint16) error {
-	return radix16b8(data, buf)
+	if err := checkBuffer(len(data), len(buf)); err != nil {
+		return err
+	}
+	if countingSortRange(data, counters(buf)) {
+		return nil
+	}
+	return sortUnsigned(data, buf, 1*8, radix16b8)
 }
 
 // radix16b8 performs the internal radix sort implementation using 8-bit buckets.
@@ -21,14 +32,27 @@ func radix16b8(data, buf []uint16) error {
 		return ErrInvalidBufferSize
 	}
 
+	// descents counts the positions where the input is not ascending,
+	// which detects presorted input without an extra pass.
+	descents := 0
+	prev := uint16(0)
+
 	// offsets[d][b] stores prefix sums (insertion offsets) for digit d and offsets b.
 	// First they are used as frequency counters, then converted into offsets.
 	offsets := [2][256]uint{}
 	for _, v := range data {
+		if v < prev {
+			descents++
+		}
+		prev = v
 		offsets[0][uint8(v>>(0*8))]++
 		offsets[1][uint8(v>>(1*8))]++
 	}
 
+	if sortPresorted(data, buf, descents, mergeRunsMax16) {
+		return nil
+	}
+
 	// Convert counts into prefix sums (offsets).
 	acc := [2]uint{offsets[0][0], offsets[1][0]}
 	offsets[0][0] = 0
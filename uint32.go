@@ -3,34 +3,59 @@ package radixsort
 // Uint32 sorts a slice of uint32 values in ascending order.
 //
 // The data slice is sorted in place. The buf slice is used for temporary
-// storage during sorting and must have len(buf) >= len(data).
+// storage during sorting. A buffer shorter than the data is accepted down to
+// [MinBufferSize] elements; the sort is then slower but uses no more memory.
 //
 // The buffer can be reused across multiple sort operations without clearing.
 //
-// Returns ErrInvalidBufferSize if len(buf) < len(data).
+// Returns ErrInvalidBufferSize if len(buf) < min(len(data), MinBufferSize).
 //
 // See [Uint64] for the 64-bit version and for usage example.
 func Uint32(data, buf []uint32) error {
-	return radix32b8(data, buf)
+	if sortSampled(data, buf, 0) {
+		return nil
+	}
+	return sortUnsigned(data, buf, 3*8, radix32)
 }
 
 // radix32b8 performs the internal radix sort implementation using 8-bit buckets.
 // The buffer length must be at least as large as data.
 func radix32b8(data, buf []uint32) error {
+	return radix32b8Base(data, buf, 0)
+}
+
+// radix32b8Base sorts data by the 8-bit digits of v-base, where base must not
+// be greater than any element. Subtracting the minimum turns a narrow range of
+// large values into small keys whose constant high bytes are skipped.
+func radix32b8Base(data, buf []uint32, base uint32) error {
 	if len(buf) < len(data) {
 		return ErrInvalidBufferSize
 	}
 
+	// descents counts the positions where the input is not ascending,
+	// which detects presorted input without an extra pass.
+	descents := 0
+	prev := uint32(0)
+
 	// offsets[d][b] stores prefix sums (insertion offsets) for digit d and offset b.
 	// First they are used as frequency counters, then converted into offsets.
 	offsets := [4][256]uint{}
 	for _, v := range data {
+		if v < prev {
+			descents++
+		}
+		prev = v
+		v -= base
 		offsets[0][uint8(v>>(0*8))]++
 		offsets[1][uint8(v>>(1*8))]++
 		offsets[2][uint8(v>>(2*8))]++
 		offsets[3][uint8(v>>(3*8))]++
 	}
 
+	if sortPresorted(data, buf, descents, mergeRunsMax32) {
+		return nil
+	}
+
 	// Calculate offsets.
 	acc := [4]uint{
 		offsets[0][0],
@@ -81,9 +106,9 @@ func radix32b8(data, buf []uint32) error {
 		swaps++
 
 		for _, v := range src {
-			index := offsets[i][uint8(v>>(i*8))]
-			dst[index] = v
-			offsets[i][uint8(v>>(i*8))]++
+			b := uint8((v - base) >> (i * 8))
+			dst[offsets[i][b]] = v
+			offsets[i][b]++
 		}
 		src, dst = dst, src
 	}
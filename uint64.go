@@ -3,11 +3,12 @@ package radixsort
 // Uint64 sorts a slice of uint64 values in ascending order.
 //
 // The data slice is sorted in place. The buf slice is used for temporary
-// storage during sorting and must have len(buf) >= len(data).
+// storage during sorting. A buffer shorter than the data is accepted down to
+// [MinBufferSize] elements; the sort is then slower but uses no more memory.
 //
 // The buffer can be reused across multiple sort operations without clearing.
 //
-// Returns ErrInvalidBufferSize if len(buf) < len(data).
+// Returns ErrInvalidBufferSize if len(buf) < min(len(data), MinBufferSize).
 //
 // Example:
 //
@@ -16,60 +17,48 @@ package radixsort
 //	err := Uint64(data, buf)
 //	// data is now sorted: [1, 2, 5, 5, 6, 9]
 func Uint64(data, buf []uint64) error {
-	return radix64b8(data, buf)
+	if sortSampled(data, buf, 0) {
+		return nil
+	}
+	return sortUnsigned(data, buf, 7*8, radix64)
 }
 
 // radix64b8 performs the internal radix sort implementation using 8-bit buckets.
 // The buffer length must be at least as large as data.
 func radix64b8(data, buf []uint64) error {
+	return radix64b8Base(data, buf, 0)
+}
+
+// radix64b8Base sorts data by the 8-bit digits of v-base, where base must not
+// be greater than any element. Subtracting the minimum turns a narrow range of
+// large values into small keys whose constant high bytes are skipped.
+func radix64b8Base(data, buf []uint64, base uint64) error {
+	return radix64b8Passes(data, buf, base, len(data) >= combinedScatterMinLen.get())
+}
+
+// radix64b8Passes is radix64b8Base with the scatter loop chosen by the
+// caller: write-combined (see scatterCombined) if combined is set.
+func radix64b8Passes(data, buf []uint64, base uint64, combined bool) error {
 	if len(buf) < len(data) {
 		return ErrInvalidBufferSize
 	}
 
 	// offsets[d][b] stores prefix sums (insertion offsets) for digit d and offsets b.
 	// First they are used as frequency counters, then converted into offsets.
+	// The histogram pass also counts the descents of the input, which detects
+	// presorted input without an extra pass.
 	offsets := [8][256]uint{}
-	for _, v := range data {
-		offsets[0][uint8(v>>(0*8))]++
-		offsets[1][uint8(v>>(1*8))]++
-		offsets[2][uint8(v>>(2*8))]++
-		offsets[3][uint8(v>>(3*8))]++
-		offsets[4][uint8(v>>(4*8))]++
-		offsets[5][uint8(v>>(5*8))]++
-		offsets[6][uint8(v>>(6*8))]++
-		offsets[7][uint8(v>>(7*8))]++
-	}
+	descents := histogram64(data, base, &offsets)
 
-	// Convert counts into prefix sums (offsets).
-	acc := [8]uint{
-		offsets[0][0],
-		offsets[1][0],
-		offsets[2][0],
-		offsets[3][0],
-		offsets[4][0],
-		offsets[5][0],
-		offsets[6][0],
-		offsets[7][0],
-	}
-	offsets[0][0] = 0
-	offsets[1][0] = 0
-	offsets[2][0] = 0
-	offsets[3][0] = 0
-	offsets[4][0] = 0
-	offsets[5][0] = 0
-	offsets[6][0] = 0
-	offsets[7][0] = 0
-	for i := 1; i < 256; i++ {
-		offsets[0][i], acc[0] = acc[0], acc[0]+offsets[0][i]
-		offsets[1][i], acc[1] = acc[1], acc[1]+offsets[1][i]
-		offsets[2][i], acc[2] = acc[2], acc[2]+offsets[2][i]
-		offsets[3][i], acc[3] = acc[3], acc[3]+offsets[3][i]
-		offsets[4][i], acc[4] = acc[4], acc[4]+offsets[4][i]
-		offsets[5][i], acc[5] = acc[5], acc[5]+offsets[5][i]
-		offsets[6][i], acc[6] = acc[6], acc[6]+offsets[6][i]
-		offsets[7][i], acc[7] = acc[7], acc[7]+offsets[7][i]
+	if sortPresorted(data, buf, descents, mergeRunsMax64) {
+		return nil
 	}
 
+	// Convert counts into prefix sums (offsets). Every digit counts all
+	// elements, so the total of each table is len(data).
+	prefixSums64(&offsets)
+	n := uint(len(data))
+
 	// Optimization: skip sorting passes where all elements in the digit are identical.
 	//
 	// Normally this is done by checking how many non-zero counters exist.
@@ -77,7 +66,7 @@ func radix64b8(data, buf []uint64) error {
 	// and sorting by this byte can be skipped.
 	//
 	// Here we use offsets instead of counters. For example:
-	// If offsets[i][1] == acc[i], it means all elements for digit i are 0,
+	// If offsets[i][1] == n, it means all elements for digit i are 0,
 	// and this sorting pass can be skipped entirely.
 	//
 	// Additionally, we count how many times the offset changes (uniqueOffsets).
@@ -89,7 +78,7 @@ func radix64b8(data, buf []uint64) error {
 	// on larger arrays, the benefit becomes insignificant :-(
 	uniqueOffsets := [8]uint{}
 	for i := range 8 {
-		if offsets[i][255] == 0 || offsets[i][1] == acc[i] {
+		if offsets[i][255] == 0 || offsets[i][1] == n {
 			uniqueOffsets[i] = 1
 			continue
 		}
@@ -99,12 +88,12 @@ func radix64b8(data, buf []uint64) error {
 				uniqueOffsets[i]++
 			}
 
-			if offsets[i][j] == acc[i] {
+			if offsets[i][j] == n {
 				break
 			}
 		}
 
-		if offsets[i][255] != acc[i] {
+		if offsets[i][255] != n {
 			uniqueOffsets[i]++
 		}
 	}
@@ -117,10 +106,14 @@ func radix64b8(data, buf []uint64) error {
 		}
 		swaps++
 
-		for _, v := range src {
-			index := offsets[i][uint8(v>>(i*8))]
-			dst[index] = v
-			offsets[i][uint8(v>>(i*8))]++
+		if combined {
+			scatterCombined(src, dst, &offsets[i], uint(i*8), base)
+		} else {
+			for _, v := range src {
+				b := uint8((v - base) >> (i * 8))
+				dst[offsets[i][b]] = v
+				offsets[i][b]++
+			}
 		}
 		src, dst = dst, src
 	}
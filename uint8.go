@@ -2,47 +2,31 @@ package radixsort
 
 // Uint8 sorts a slice of uint8 values in ascending order.
 //
-// The data slice is sorted in place. The buf slice is used for temporary
-// storage during sorting and must have len(buf) >= len(data).
+// The data slice is sorted in place by counting the values and writing each
+// of them back as often as it occurs. The buf slice is ignored: any buffer,
+// including nil, is accepted, and it remains a parameter only to keep the
+// sorts interchangeable. [InPlaceUint8] sorts the same way.
 //
-// The buffer can be reused across multiple sort operations without clearing.
-//
-// Returns ErrInvalidBufferSize if len(buf) < len(data).
+// The returned error is always nil.
 //
 // See [Uint64] for the 64-bit version and for usage example.
 func Uint8(data, buf []uint8) error {
-	return radix8(data, buf)
+	if isSmall(len(data), 1) {
+		insertionSort(data)
+		return nil
+	}
+
+	countingSort8(data)
+	return nil
 }
 
-// radix8b8 performs the internal radix sort implementation using 8-bit buckets.
-// The buffer length must be at least as large as data.
+// radix8 sorts data by counting its values, see [countingSort8]. The buffer
+// is only validated, never written, so that radix8 can serve as a kernel.
 func radix8(data, buf []uint8) error {
 	if len(buf) < len(data) {
 		return ErrInvalidBufferSize
 	}
 
-	// offsets[d][b] stores prefix sums (insertion offsets) for digit d and offsets b.
-	// First they are used as frequency counters, then converted into offsets.
-	offsets := [256]uint{}
-	for _, v := range data {
-		offsets[v]++
-	}
-
-	// Calculate offsets.
-	acc := offsets[0]
-	offsets[0] = 0
-	for i := 1; i < len(offsets); i++ {
-		offsets[i], acc = acc, acc+offsets[i]
-	}
-
-	dst := buf[:len(data)]
-	for _, v := range data {
-		index := offsets[v]
-		dst[index] = v
-		offsets[v]++
-	}
-
-	copy(data, dst)
-
+	countingSort8(data)
 	return nil
 }
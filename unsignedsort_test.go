@@ -44,7 +44,7 @@ func TestUint64LargeRandom(t *testing.T) {
 }
 
 func TestUint8BufferSize(t *testing.T) {
-	testSortBufferSize(t, radixsort.Uint8, "Uint8")
+	testSortIgnoresBuffer(t, radixsort.Uint8, "Uint8", 10)
 }
 
 func TestUint16BufferSize(t *testing.T) {
@@ -189,3 +189,37 @@ func testSortBufferSize[T constraints.Integer, B constraints.Unsigned](t *testin
 		})
 	}
 }
+
+// testSortIgnoresBuffer checks that sortFunc, which does not use its buffer,
+// sorts size elements without error whatever buffer it is given, including
+// nil.
+func testSortIgnoresBuffer[T constraints.Integer, B constraints.Unsigned](t *testing.T, sortFunc func([]T, []B) error, sortFuncName string, size int) {
+	input := make([]T, size)
+	for i := range input {
+		input[i] = T(rand.Uint64())
+	}
+
+	tests := []struct {
+		name string
+		buf  []B
+	}{
+		{name: "NilBuffer", buf: nil},
+		{name: "EmptyBuffer", buf: []B{}},
+		{name: "BufferBelowMinimum", buf: make([]B, min(size, radixsort.MinBufferSize)-1)},
+		{name: "BufferTooSmall", buf: make([]B, size/2)},
+		{name: "BufferCorrectSize", buf: make([]B, size)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data := slices.Clone(input)
+
+			if err := sortFunc(data, tc.buf); err != nil {
+				t.Errorf("%s: error = %v, want nil", sortFuncName, err)
+			}
+			if !slices.IsSorted(data) {
+				t.Errorf("%s: data is not sorted", sortFuncName)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package radixsort
+
+import (
+	"unsafe"
+
+	"github.com/sagernet/sing/common/x/constraints"
+)
+
+// Longest inputs that the Unstable functions sort with in-place American flag
+// sort even when a full buffer is given, by key width. Up to a few thousand
+// elements, partitioning from the most significant byte finishes most buckets
+// with insertion sort after one or two passes, while LSD sorts pay for every
+// non-constant byte. Beyond that the LSD kernels win (see BenchmarkUnstable).
+const (
+	unstableMSDMax32 = 1 << 11
+	unstableMSDMax64 = 1 << 12
+)
+
+// UnstableUint64 sorts a slice of uint64 values in ascending order, choosing
+// whichever algorithm is fastest for the input without regard to stability.
+//
+// Equal integers are indistinguishable, so the result is the same as that of
+// [Uint64]; what the caller gives up is the guarantee of which algorithm
+// runs, in exchange for:
+//
+//   - Counting sort for inputs of a narrow value range, whatever the width.
+//   - In-place American flag sort (see [InPlaceUint64]) for short inputs,
+//     where it beats the LSD passes.
+//   - No error for a short buffer: any buffer, including nil, is accepted.
+//     With len(buf) < len(data), the data is partitioned in place until the
+//     buckets fit into buf, as in the bounded memory mode of Uint64.
+//
+// Long inputs with a full buffer are sorted like Uint64.
+//
+// Example:
+//
+//	UnstableUint64(data, nil) // sorts without any scratch memory
+func UnstableUint64(data, buf []uint64) {
+	if countingSortRange(data, counters(buf)) || unstableInPlace(data, buf, 0, 7*8, unstableMSDMax64, radix64) {
+		return
+	}
+	_ = Uint64(data, buf)
+}
+
+// UnstableUint32 sorts a slice of uint32 values in ascending order, choosing
+// the fastest algorithm regardless of stability. See [UnstableUint64].
+func UnstableUint32(data, buf []uint32) {
+	if countingSortRange(data, counters(buf)) || unstableInPlace(data, buf, 0, 3*8, unstableMSDMax32, radix32) {
+		return
+	}
+	_ = Uint32(data, buf)
+}
+
+// UnstableUint16 sorts a slice of uint16 values in ascending order, choosing
+// the fastest algorithm regardless of stability. See [UnstableUint64].
+func UnstableUint16(data, buf []uint16) {
+	if countingSortRange(data, counters(buf)) || unstableInPlace(data, buf, 0, 1*8, unstableMSDMax32, radix16b8) {
+		return
+	}
+	_ = Uint16(data, buf)
+}
+
+// UnstableUint8 sorts a slice of uint8 values in ascending order by counting,
+// which needs no buffer; buf is ignored. See [UnstableUint64].
+func UnstableUint8(data, buf []uint8) {
+	countingSort8(data)
+}
+
+// UnstableInt64 sorts a slice of int64 values in ascending order, choosing
+// the fastest algorithm regardless of stability. See [UnstableUint64].
+func UnstableInt64(data []int64, buf []uint64) {
+	if countingSortRange(data, counters(buf)) {
+		return
+	}
+	unsignedData := *(*[]uint64)(unsafe.Pointer(&data))
+	if unstableInPlace(unsignedData, buf, 1<<63, 7*8, unstableMSDMax64, radix64) {
+		return
+	}
+	_ = Int64(data, buf)
+}
+
+// UnstableInt32 sorts a slice of int32 values in ascending order, choosing
+// the fastest algorithm regardless of stability. See [UnstableUint64].
+func UnstableInt32(data []int32, buf []uint32) {
+	if countingSortRange(data, counters(buf)) {
+		return
+	}
+	unsignedData := *(*[]uint32)(unsafe.Pointer(&data))
+	if unstableInPlace(unsignedData, buf, 1<<31, 3*8, unstableMSDMax32, radix32) {
+		return
+	}
+	_ = Int32(data, buf)
+}
+
+// UnstableInt16 sorts a slice of int16 values in ascending order, choosing
+// the fastest algorithm regardless of stability. See [UnstableUint64].
+func UnstableInt16(data []int16, buf []uint16) {
+	if countingSortRange(data, counters(buf)) {
+		return
+	}
+	unsignedData := *(*[]uint16)(unsafe.Pointer(&data))
+	if unstableInPlace(unsignedData, buf, 1<<15, 1*8, unstableMSDMax32, radix16b8) {
+		return
+	}
+	_ = Int16(data, buf)
+}
+
+// UnstableInt8 sorts a slice of int8 values in ascending order by counting,
+// which needs no buffer; buf is ignored. See [UnstableUint64].
+func UnstableInt8(data []int8, buf []uint8) {
+	countingSort8(data)
+}
+
+// UnstableFloat64 sorts a slice of float64 values in the order of
+// [OrderedKey], choosing the fastest algorithm regardless of stability.
+// Floats that compare equal but differ in their bits, -0 and +0, are ordered
+// by their keys, so the result equals that of [Parallel.Float64]. See
+// [UnstableUint64].
+func UnstableFloat64(data []float64, buf []uint64) {
+	keys := *(*[]uint64)(unsafe.Pointer(&data))
+	floatKeys(keys, 1)
+	UnstableUint64(keys, buf)
+	floatValues(keys, 1)
+}
+
+// UnstableFloat32 sorts a slice of float32 values like [UnstableFloat64].
+func UnstableFloat32(data []float32, buf []uint32) {
+	keys := *(*[]uint32)(unsafe.Pointer(&data))
+	floatKeys(keys, 1)
+	UnstableUint32(keys, buf)
+	floatValues(keys, 1)
+}
+
+// unstableInPlace sorts data with American flag sort if it is short and its
+// keys vary in more than two bytes, or if buf is shorter than data, and
+// reports whether it did. The integers in data have
+// the sign bit signBit, or are unsigned if it is zero; shift is the position
+// of their most significant byte. Buckets that fit into buf are finished with
+// kernel, the LSD sort of the unsigned representation.
+func unstableInPlace[T constraints.Unsigned](data, buf []T, signBit T, shift uint, msdMax int, kernel func(data, buf []T) error) bool {
+	if len(buf) >= len(data) {
+		if len(data) > msdMax {
+			return false
+		}
+		// Keys that vary in at most two bytes take at most two LSD passes,
+		// which the partitioning does not beat.
+		_, _, diff := keyRange(data)
+		if varyingBytes(uint64(diff)) <= 2 {
+			return false
+		}
+		buf = nil
+	}
+
+	// Flipping the sign bit orders negative values first.
+	if signBit != 0 {
+		flipBits(data, signBit)
+	}
+	americanFlag(data, buf, shift, kernel)
+	if signBit != 0 {
+		flipBits(data, signBit)
+	}
+	return true
+}
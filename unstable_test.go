@@ -0,0 +1,97 @@
+package radixsort_test
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/Kaidzen-62/radixsort"
+	"golang.org/x/exp/constraints"
+)
+
+// unstableSizes cover the in-place path for short inputs, the counting path
+// and the LSD path.
+var unstableSizes = []int{0, 1, 2, 31, 100, 2000, 4096, 5000, 70_000}
+
+func testUnstableSort[T constraints.Integer, B constraints.Unsigned](t *testing.T, sortFunc func([]T, []B), sortFuncName string) {
+	gens := map[string]func() T{
+		"random": func() T { return T(rand.Uint64()) },
+		"narrow": func() T { return T(rand.Intn(1000)) },
+		// Narrow around zero for signed types, the extremes for unsigned.
+		"around_zero": func() T { return T(rand.Intn(1000) - 500) },
+		"wide":        func() T { return T(rand.Intn(1 << 20)) },
+	}
+	for name, gen := range gens {
+		for _, size := range unstableSizes {
+			input := make([]T, size)
+			for i := range input {
+				input[i] = gen()
+			}
+			want := slices.Clone(input)
+			slices.Sort(want)
+
+			for _, bufSize := range []int{-1, 0, 100, size / 2, size} {
+				if bufSize > size {
+					continue
+				}
+				var buf []B
+				if bufSize >= 0 {
+					buf = make([]B, bufSize)
+				}
+				data := slices.Clone(input)
+				sortFunc(data, buf)
+				if !slices.Equal(want, data) {
+					t.Errorf("%s of %d %s values with buffer of %d failed", sortFuncName, size, name, bufSize)
+				}
+
+				// Descending input, with runs of equal values.
+				slices.Reverse(data)
+				sortFunc(data, buf)
+				if !slices.Equal(want, data) {
+					t.Errorf("%s of %d descending %s values with buffer of %d failed", sortFuncName, size, name, bufSize)
+				}
+			}
+		}
+	}
+}
+
+func TestUnstable(t *testing.T) {
+	testUnstableSort(t, radixsort.UnstableUint8, "UnstableUint8")
+	testUnstableSort(t, radixsort.UnstableUint16, "UnstableUint16")
+	testUnstableSort(t, radixsort.UnstableUint32, "UnstableUint32")
+	testUnstableSort(t, radixsort.UnstableUint64, "UnstableUint64")
+	testUnstableSort(t, radixsort.UnstableInt8, "UnstableInt8")
+	testUnstableSort(t, radixsort.UnstableInt16, "UnstableInt16")
+	testUnstableSort(t, radixsort.UnstableInt32, "UnstableInt32")
+	testUnstableSort(t, radixsort.UnstableInt64, "UnstableInt64")
+}
+
+func TestUnstableFloat(t *testing.T) {
+	for _, size := range unstableSizes {
+		data := make([]float64, size)
+		for i := range data {
+			switch rand.Intn(10) {
+			case 0:
+				data[i] = math.Copysign(0, -1)
+			case 1:
+				data[i] = math.Inf(1)
+			default:
+				data[i] = rand.NormFloat64()
+			}
+		}
+		data32 := make([]float32, size)
+		for i, v := range data {
+			data32[i] = float32(v)
+		}
+
+		radixsort.UnstableFloat64(data, nil)
+		radixsort.UnstableFloat32(data32, make([]uint32, size))
+		byKey64 := func(a, b float64) int { return cmp.Compare(radixsort.OrderedKey(a), radixsort.OrderedKey(b)) }
+		byKey32 := func(a, b float32) int { return cmp.Compare(radixsort.OrderedKey(a), radixsort.OrderedKey(b)) }
+		if !slices.IsSortedFunc(data, byKey64) || !slices.IsSortedFunc(data32, byKey32) {
+			t.Errorf("unstable float sort of %d values failed", size)
+		}
+	}
+}